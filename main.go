@@ -5,7 +5,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
+// version is stamped in by goreleaser at build time.
+var version = "dev"
+
 func main() {
+	slack.Version = version
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: slack.Provider,
 	})
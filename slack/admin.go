@@ -0,0 +1,125 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// slackAdminClient is the subset of the admin.* Web API used by the
+// provider. slack-go does not implement these methods, so they are called
+// directly over HTTP with the admin token.
+type slackAdminClient interface {
+	ConvertConversationToPrivateContext(ctx context.Context, channelID string) error
+	ConvertConversationToPublicContext(ctx context.Context, channelID string) error
+	DeleteConversationContext(ctx context.Context, channelID string) error
+}
+
+// adminAPIClient calls admin.* Web API methods. Errors use the slack-go
+// error types, so retries and error classification work as for other calls.
+type adminAPIClient struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+}
+
+var _ slackAdminClient = (*adminAPIClient)(nil)
+
+func newAdminAPIClient(httpClient *http.Client, apiURL, token string) *adminAPIClient {
+	return &adminAPIClient{
+		httpClient: httpClient,
+		apiURL:     apiURL,
+		token:      token,
+	}
+}
+
+func (c *adminAPIClient) ConvertConversationToPrivateContext(ctx context.Context, channelID string) error {
+	return c.post(ctx, "admin.conversations.convertToPrivate", url.Values{"channel_id": {channelID}})
+}
+
+func (c *adminAPIClient) ConvertConversationToPublicContext(ctx context.Context, channelID string) error {
+	return c.post(ctx, "admin.conversations.convertToPublic", url.Values{"channel_id": {channelID}})
+}
+
+func (c *adminAPIClient) DeleteConversationContext(ctx context.Context, channelID string) error {
+	return c.post(ctx, "admin.conversations.delete", url.Values{"channel_id": {channelID}})
+}
+
+// post calls method with values and checks the {"ok": ..., "error": ...}
+// envelope of the response.
+func (c *adminAPIClient) post(ctx context.Context, method string, values url.Values) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+method, strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &slack.RateLimitedError{RetryAfter: time.Duration(retryAfter) * time.Second}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return slack.StatusCodeError{Code: resp.StatusCode, Status: resp.Status}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var envelope slack.SlackResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("decoding %s response: %w", method, err)
+	}
+	if !envelope.Ok {
+		return slack.SlackErrorResponse{Err: envelope.Error, ResponseMetadata: envelope.ResponseMetadata}
+	}
+	return nil
+}
+
+// retryAdminClient adds the retry behaviour of retryClient to admin calls.
+type retryAdminClient struct {
+	api     slackAdminClient
+	retrier *retryClient
+}
+
+var _ slackAdminClient = (*retryAdminClient)(nil)
+
+func newRetryAdminClient(api slackAdminClient, maxRetries int, timeout time.Duration) *retryAdminClient {
+	return &retryAdminClient{
+		api:     api,
+		retrier: newRetryClient(nil, maxRetries, timeout),
+	}
+}
+
+func (c *retryAdminClient) ConvertConversationToPrivateContext(ctx context.Context, channelID string) error {
+	return c.retrier.retry(ctx, "admin.conversations.convertToPrivate", func(ctx context.Context) error {
+		return c.api.ConvertConversationToPrivateContext(ctx, channelID)
+	})
+}
+
+func (c *retryAdminClient) ConvertConversationToPublicContext(ctx context.Context, channelID string) error {
+	return c.retrier.retry(ctx, "admin.conversations.convertToPublic", func(ctx context.Context) error {
+		return c.api.ConvertConversationToPublicContext(ctx, channelID)
+	})
+}
+
+func (c *retryAdminClient) DeleteConversationContext(ctx context.Context, channelID string) error {
+	return c.retrier.retry(ctx, "admin.conversations.delete", func(ctx context.Context) error {
+		return c.api.DeleteConversationContext(ctx, channelID)
+	})
+}
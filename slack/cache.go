@@ -0,0 +1,300 @@
+package slack
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// workspaceCache holds workspace-wide lookups that are expensive to repeat
+// for every resource in a plan: the channel list, channel and usergroup
+// member lists, the user directory, the usergroup index and the bot
+// identity. Each entry is filled lazily on first
+// use and shared by all resources and data sources of one provider instance.
+// Writes that change an entry must invalidate it so the next lookup
+// refetches it.
+type workspaceCache struct {
+	api slackClient
+
+	channelsMu     sync.Mutex
+	channels       []slack.Channel
+	channelsLoaded bool
+
+	channelMembersMu sync.Mutex
+	channelMembers   map[string][]string
+
+	usersMu     sync.Mutex
+	users       []slack.User
+	usersByID   map[string]*slack.User
+	usersByMail map[string]*slack.User
+
+	usergroupsMu   sync.Mutex
+	usergroups     []slack.UserGroup
+	usergroupsByID map[string]*slack.UserGroup
+
+	usergroupMembersMu sync.Mutex
+	usergroupMembers   map[string][]string
+
+	botMu sync.Mutex
+	bot   *slack.AuthTestResponse
+}
+
+func newWorkspaceCache(api slackClient) *workspaceCache {
+	return &workspaceCache{api: api}
+}
+
+// Channels returns every public and private channel in the workspace,
+// including archived ones.
+func (c *workspaceCache) Channels(ctx context.Context) ([]slack.Channel, error) {
+	c.channelsMu.Lock()
+	defer c.channelsMu.Unlock()
+
+	if c.channelsLoaded {
+		return c.channels, nil
+	}
+
+	var channels []slack.Channel
+	cursor := ""
+	for {
+		page, nextCursor, err := c.api.GetConversationsContext(ctx, &slack.GetConversationsParameters{
+			ExcludeArchived: false,
+			Limit:           1000,
+			Cursor:          cursor,
+			Types:           []string{"public_channel", "private_channel"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, page...)
+
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	c.channels = channels
+	c.channelsLoaded = true
+	return c.channels, nil
+}
+
+// InvalidateChannels drops the cached channel list. Call it after creating,
+// renaming, archiving or unarchiving a channel.
+func (c *workspaceCache) InvalidateChannels() {
+	c.channelsMu.Lock()
+	defer c.channelsMu.Unlock()
+
+	c.channels = nil
+	c.channelsLoaded = false
+}
+
+// ChannelMembers returns the IDs of the members of the channel.
+func (c *workspaceCache) ChannelMembers(ctx context.Context, channelID string) ([]string, error) {
+	c.channelMembersMu.Lock()
+	defer c.channelMembersMu.Unlock()
+
+	if members, ok := c.channelMembers[channelID]; ok {
+		return members, nil
+	}
+
+	members, err := getChannelMembers(ctx, c.api, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if c.channelMembers == nil {
+		c.channelMembers = make(map[string][]string)
+	}
+	c.channelMembers[channelID] = members
+	return members, nil
+}
+
+// InvalidateChannelMembers drops the cached member list of the channel. Call
+// it after inviting users to or removing users from the channel.
+func (c *workspaceCache) InvalidateChannelMembers(channelID string) {
+	c.channelMembersMu.Lock()
+	defer c.channelMembersMu.Unlock()
+
+	delete(c.channelMembers, channelID)
+}
+
+// Users returns the workspace user directory.
+func (c *workspaceCache) Users(ctx context.Context) ([]slack.User, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	if err := c.loadUsers(ctx); err != nil {
+		return nil, err
+	}
+	return c.users, nil
+}
+
+// UserByID returns the user with the given ID, or nil if there is none.
+func (c *workspaceCache) UserByID(ctx context.Context, id string) (*slack.User, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	if err := c.loadUsers(ctx); err != nil {
+		return nil, err
+	}
+	return c.usersByID[id], nil
+}
+
+// UserByEmail returns the user with the given email address, or nil if
+// there is none. Emails are compared case-insensitively.
+func (c *workspaceCache) UserByEmail(ctx context.Context, email string) (*slack.User, error) {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	if err := c.loadUsers(ctx); err != nil {
+		return nil, err
+	}
+	return c.usersByMail[strings.ToLower(email)], nil
+}
+
+// loadUsers fills the user directory. Callers hold usersMu.
+func (c *workspaceCache) loadUsers(ctx context.Context) error {
+	if c.usersByID != nil {
+		return nil
+	}
+
+	users, err := c.api.GetUsersContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*slack.User, len(users))
+	byMail := make(map[string]*slack.User, len(users))
+	for i := range users {
+		byID[users[i].ID] = &users[i]
+		if email := users[i].Profile.Email; email != "" {
+			byMail[strings.ToLower(email)] = &users[i]
+		}
+	}
+
+	c.users = users
+	c.usersByID = byID
+	c.usersByMail = byMail
+	return nil
+}
+
+// InvalidateUsers drops the cached user directory.
+func (c *workspaceCache) InvalidateUsers() {
+	c.usersMu.Lock()
+	defer c.usersMu.Unlock()
+
+	c.users = nil
+	c.usersByID = nil
+	c.usersByMail = nil
+}
+
+// Usergroups returns every usergroup in the workspace, including disabled
+// ones. Members are not included; use usergroups.users.list for those.
+func (c *workspaceCache) Usergroups(ctx context.Context) ([]slack.UserGroup, error) {
+	c.usergroupsMu.Lock()
+	defer c.usergroupsMu.Unlock()
+
+	if err := c.loadUsergroups(ctx); err != nil {
+		return nil, err
+	}
+	return c.usergroups, nil
+}
+
+// UsergroupByID returns the usergroup with the given ID, or nil if there is
+// none.
+func (c *workspaceCache) UsergroupByID(ctx context.Context, id string) (*slack.UserGroup, error) {
+	c.usergroupsMu.Lock()
+	defer c.usergroupsMu.Unlock()
+
+	if err := c.loadUsergroups(ctx); err != nil {
+		return nil, err
+	}
+	return c.usergroupsByID[id], nil
+}
+
+// loadUsergroups fills the usergroup index. Callers hold usergroupsMu.
+func (c *workspaceCache) loadUsergroups(ctx context.Context) error {
+	if c.usergroupsByID != nil {
+		return nil
+	}
+
+	usergroups, err := c.api.GetUserGroupsContext(ctx, slack.GetUserGroupsOptionIncludeDisabled(true))
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*slack.UserGroup, len(usergroups))
+	for i := range usergroups {
+		byID[usergroups[i].ID] = &usergroups[i]
+	}
+
+	c.usergroups = usergroups
+	c.usergroupsByID = byID
+	return nil
+}
+
+// InvalidateUsergroups drops the cached usergroup index. Call it after
+// creating, updating, enabling or disabling a usergroup.
+func (c *workspaceCache) InvalidateUsergroups() {
+	c.usergroupsMu.Lock()
+	defer c.usergroupsMu.Unlock()
+
+	c.usergroups = nil
+	c.usergroupsByID = nil
+}
+
+// UsergroupMembers returns the IDs of the members of the usergroup.
+func (c *workspaceCache) UsergroupMembers(ctx context.Context, id string) ([]string, error) {
+	c.usergroupMembersMu.Lock()
+	defer c.usergroupMembersMu.Unlock()
+
+	if members, ok := c.usergroupMembers[id]; ok {
+		return members, nil
+	}
+
+	members, err := c.api.GetUserGroupMembersContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.usergroupMembers == nil {
+		c.usergroupMembers = make(map[string][]string)
+	}
+	c.usergroupMembers[id] = members
+	return members, nil
+}
+
+// InvalidateUsergroupMembers drops the cached member list of the usergroup.
+// Call it after updating the usergroup's members.
+func (c *workspaceCache) InvalidateUsergroupMembers(id string) {
+	c.usergroupMembersMu.Lock()
+	defer c.usergroupMembersMu.Unlock()
+
+	delete(c.usergroupMembers, id)
+}
+
+// Bot returns the identity of the token the provider authenticates with.
+func (c *workspaceCache) Bot(ctx context.Context) (*slack.AuthTestResponse, error) {
+	c.botMu.Lock()
+	defer c.botMu.Unlock()
+
+	if c.bot != nil {
+		return c.bot, nil
+	}
+
+	bot, err := c.api.AuthTestContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.bot = bot
+	return c.bot, nil
+}
+
+// setBot seeds the bot identity, typically from the auth.test call made
+// while configuring the provider.
+func (c *workspaceCache) setBot(bot *slack.AuthTestResponse) {
+	c.botMu.Lock()
+	defer c.botMu.Unlock()
+
+	c.bot = bot
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/slack-go/slack"
 )
 
 func dataSourceSlackChannel() *schema.Resource {
@@ -33,17 +32,21 @@ func dataSourceSlackChannel() *schema.Resource {
 }
 
 func dataSourceSlackChannelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	if diags := checkDataSourceScopes(meta, "slack_channel"); diags != nil {
+		return diags
+	}
+
+	cache := meta.(*providerMeta).cache
 	name := d.Get("name").(string)
 
-	channel, debugLogs, err := findChannelByName(ctx, api, name)
+	channel, debugLogs, err := findChannelByName(ctx, cache, name)
 	for _, msg := range debugLogs {
 		// Optional: debug log
 		tflog.Debug(ctx, msg)
 	}
 
 	if err != nil {
-		return diag.Errorf("error searching for Slack channel: %s", err)
+		return slackDiagErrorf(err, "conversations.list", "error searching for Slack channel")
 	}
 
 	if channel == nil {
@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/slack-go/slack"
 )
 
 func dataSourceSlackChannels() *schema.Resource {
@@ -65,7 +64,11 @@ func dataSourceSlackChannels() *schema.Resource {
 }
 
 func dataSourceSlackChannelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	if diags := checkDataSourceScopes(meta, "slack_channels"); diags != nil {
+		return diags
+	}
+
+	cache := meta.(*providerMeta).cache
 	var diags diag.Diagnostics
 
 	var allChannels []map[string]interface{}
@@ -77,41 +80,31 @@ func dataSourceSlackChannelsRead(ctx context.Context, d *schema.ResourceData, me
 		limit = v.(int)
 	}
 
-	cursor := ""
-	for {
-		channels, nextCursor, err := api.GetConversations(&slack.GetConversationsParameters{
-			ExcludeArchived: !includeArchived,
-			Limit:           1000,
-			Cursor:          cursor,
-			Types:           []string{"public_channel", "private_channel"},
-		})
-		if err != nil {
-			return diag.Errorf("failed to list Slack channels: %s", err)
-		}
-
-		for _, c := range channels {
-			if prefix != "" && !strings.HasPrefix(c.Name, prefix) {
-				continue
-			}
-			if hasPrivacy && c.IsPrivate != filterPrivate.(bool) {
-				continue
-			}
-			allChannels = append(allChannels, map[string]interface{}{
-				"id":          c.ID,
-				"name":        c.Name,
-				"is_private":  c.IsPrivate,
-				"is_archived": c.IsArchived,
-			})
+	channels, err := cache.Channels(ctx)
+	if err != nil {
+		return slackDiagErrorf(err, "conversations.list", "failed to list Slack channels")
+	}
 
-			if limit > 0 && len(allChannels) >= limit {
-				break
-			}
+	for _, c := range channels {
+		if !includeArchived && c.IsArchived {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(c.Name, prefix) {
+			continue
 		}
+		if hasPrivacy && c.IsPrivate != filterPrivate.(bool) {
+			continue
+		}
+		allChannels = append(allChannels, map[string]interface{}{
+			"id":          c.ID,
+			"name":        c.Name,
+			"is_private":  c.IsPrivate,
+			"is_archived": c.IsArchived,
+		})
 
-		if nextCursor == "" || (limit > 0 && len(allChannels) >= limit) {
+		if limit > 0 && len(allChannels) >= limit {
 			break
 		}
-		cursor = nextCursor
 	}
 
 	if err := d.Set("channels", allChannels); err != nil {
@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/slack-go/slack"
 )
 
 func dataSourceSlackUser() *schema.Resource {
@@ -51,15 +50,24 @@ func dataSourceSlackUser() *schema.Resource {
 }
 
 func dataSourceSlackUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	if diags := checkDataSourceScopes(meta, "slack_user"); diags != nil {
+		return diags
+	}
+
+	m := meta.(*providerMeta)
 	var diags diag.Diagnostics
 
 	email := d.Get("email").(string)
 	tflog.Info(ctx, fmt.Sprintf("Searching for Slack user with email: %s", email))
 
-	user, err := api.GetUserByEmail(email)
+	// Look the user up in the cached directory, so data sources for many
+	// users share one users.list call
+	user, err := m.cache.UserByEmail(ctx, email)
 	if err != nil {
-		return diag.Errorf("error retrieving Slack user by email '%s': %s", email, err)
+		return slackDiagErrorf(err, "users.list", "error retrieving Slack user by email '%s'", email)
+	}
+	if user == nil {
+		return diag.Errorf("Slack user with email '%s' not found", email)
 	}
 
 	d.SetId(user.ID)
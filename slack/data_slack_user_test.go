@@ -0,0 +1,39 @@
+package slack
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSlackUserRead(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "alice@example.com")
+	fake.addUser("U002", "bob", "bob@example.com")
+	ctx := context.Background()
+	meta := fake.meta()
+
+	for id, email := range map[string]string{"U001": "alice@example.com", "U002": "Bob@Example.com"} {
+		d := schema.TestResourceDataRaw(t, dataSourceSlackUser().Schema, map[string]interface{}{"email": email})
+		if diags := dataSourceSlackUserRead(ctx, d, meta); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", email, diags)
+		}
+		if d.Id() != id {
+			t.Errorf("%s: expected user %s, got %s", email, id, d.Id())
+		}
+	}
+	if got := fake.callCount("users.list"); got != 1 {
+		t.Errorf("expected the user directory to be listed once, got %d calls", got)
+	}
+	if got := fake.callCount("users.lookupByEmail"); got != 0 {
+		t.Errorf("expected no users.lookupByEmail calls, got %d", got)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceSlackUser().Schema, map[string]interface{}{"email": "carol@example.com"})
+	diags := dataSourceSlackUserRead(ctx, d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "not found") {
+		t.Errorf("expected a not found error, got %v", diags)
+	}
+}
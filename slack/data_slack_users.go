@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/slack-go/slack"
 )
 
 func dataSourceSlackUsers() *schema.Resource {
@@ -41,7 +40,11 @@ func dataSourceSlackUsers() *schema.Resource {
 }
 
 func dataSourceSlackUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	if diags := checkDataSourceScopes(meta, "slack_users"); diags != nil {
+		return diags
+	}
+
+	cache := meta.(*providerMeta).cache
 	var diags diag.Diagnostics
 
 	domainFilter := d.Get("domain_filter").(string)
@@ -51,9 +54,9 @@ func dataSourceSlackUsersRead(ctx context.Context, d *schema.ResourceData, meta
 		tflog.Info(ctx, "No domain filter applied, returning all users")
 	}
 
-	users, err := api.GetUsers()
+	users, err := cache.Users(ctx)
 	if err != nil {
-		return diag.Errorf("error retrieving Slack users: %s", err)
+		return slackDiagErrorf(err, "users.list", "error retrieving Slack users")
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Fetched %d users from Slack API", len(users)))
 
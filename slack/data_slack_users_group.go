@@ -9,7 +9,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/slack-go/slack"
 )
 
 func dataSourceSlackUsersGroup() *schema.Resource {
@@ -33,7 +32,11 @@ func dataSourceSlackUsersGroup() *schema.Resource {
 }
 
 func dataSourceSlackUsersGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	if diags := checkDataSourceScopes(meta, "slack_users_group"); diags != nil {
+		return diags
+	}
+
+	cache := meta.(*providerMeta).cache
 	var diags diag.Diagnostics
 
 	rawEmails := d.Get("emails").([]interface{})
@@ -42,8 +45,11 @@ func dataSourceSlackUsersGroupRead(ctx context.Context, d *schema.ResourceData,
 
 	for _, raw := range rawEmails {
 		email := raw.(string)
-		user, err := api.GetUserByEmail(email)
+		user, err := cache.UserByEmail(ctx, email)
 		if err != nil {
+			return slackDiagErrorf(err, "users.list", "error retrieving Slack users")
+		}
+		if user == nil {
 			missing = append(missing, email)
 			continue
 		}
@@ -0,0 +1,144 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+func dataSourceSlackUsergroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSlackUsergroupRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "handle", "name"},
+				Description:  "The ID of the usergroup to look up.",
+			},
+			"handle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The handle of the usergroup to look up, without the leading @.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The display name of the usergroup to look up.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the usergroup.",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The team ID the usergroup belongs to.",
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the members of the usergroup. Empty for a disabled usergroup.",
+			},
+			"channels": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the default channels of the usergroup.",
+			},
+			"is_external": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the usergroup is shared from another workspace.",
+			},
+			"date_create": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "When the usergroup was created, as a Unix timestamp.",
+			},
+			"auto_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Set for usergroups Slack maintains itself, such as \"admin\" or \"owner\". Empty for regular usergroups.",
+			},
+		},
+	}
+}
+
+func dataSourceSlackUsergroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := checkDataSourceScopes(meta, "slack_usergroup"); diags != nil {
+		return diags
+	}
+
+	m := meta.(*providerMeta)
+	id := d.Get("id").(string)
+	handle := d.Get("handle").(string)
+	name := d.Get("name").(string)
+
+	usergroups, err := m.cache.Usergroups(ctx)
+	if err != nil {
+		return slackDiagErrorf(err, "usergroups.list", "error listing usergroups")
+	}
+
+	var usergroup *slack.UserGroup
+	for i := range usergroups {
+		ug := &usergroups[i]
+		if (id != "" && ug.ID == id) || (handle != "" && ug.Handle == handle) || (name != "" && ug.Name == name) {
+			usergroup = ug
+			break
+		}
+	}
+
+	if usergroup == nil {
+		switch {
+		case id != "":
+			return diag.Errorf("Slack usergroup with ID '%s' not found", id)
+		case handle != "":
+			return diag.Errorf("Slack usergroup with handle '%s' not found", handle)
+		default:
+			return diag.Errorf("Slack usergroup with name '%s' not found", name)
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found usergroup %s (@%s)", usergroup.ID, usergroup.Handle))
+
+	// Members of a disabled usergroup cannot be listed
+	members := []string{}
+	if usergroup.DateDelete == 0 {
+		members, err = m.client.GetUserGroupMembersContext(ctx, usergroup.ID)
+		if err != nil {
+			return slackDiagErrorf(err, "usergroups.users.list", "error reading members of usergroup %s", usergroup.ID)
+		}
+	}
+
+	d.SetId(usergroup.ID)
+
+	set := func(key string, val interface{}) {
+		if err := d.Set(key, val); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Failed to set '%s': %s", key, err))
+		}
+	}
+
+	set("handle", usergroup.Handle)
+	set("name", usergroup.Name)
+	set("description", usergroup.Description)
+	set("team_id", usergroup.TeamID)
+	set("members", members)
+	set("channels", usergroup.Prefs.Channels)
+	set("is_external", usergroup.IsExternal)
+	set("date_create", int(usergroup.DateCreate))
+	set("auto_type", usergroup.AutoType)
+
+	return nil
+}
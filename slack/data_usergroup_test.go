@@ -0,0 +1,82 @@
+package slack
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSlackUsergroupRead(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	fake.addUsergroup("ops", "U002")
+	id := fake.addUsergroup("devs", "U001", "U002")
+	fake.mu.Lock()
+	fake.usergroups[id].Name = "Developers"
+	fake.usergroups[id].Description = "All developers"
+	fake.usergroups[id].Prefs.Channels = []string{"C001"}
+	fake.usergroups[id].DateCreate = 1700000000
+	fake.mu.Unlock()
+	ctx := context.Background()
+	meta := fake.meta()
+
+	for _, cfg := range []map[string]interface{}{
+		{"id": id},
+		{"handle": "devs"},
+		{"name": "Developers"},
+	} {
+		d := schema.TestResourceDataRaw(t, dataSourceSlackUsergroup().Schema, cfg)
+		if diags := dataSourceSlackUsergroupRead(ctx, d, meta); diags.HasError() {
+			t.Fatalf("%v: unexpected error: %v", cfg, diags)
+		}
+		if d.Id() != id {
+			t.Errorf("%v: expected usergroup %s, got %s", cfg, id, d.Id())
+		}
+		if got := d.Get("handle").(string); got != "devs" {
+			t.Errorf("%v: expected handle devs, got %q", cfg, got)
+		}
+		if got := d.Get("description").(string); got != "All developers" {
+			t.Errorf("%v: expected description, got %q", cfg, got)
+		}
+		if got := d.Get("team_id").(string); got != fakeTeamID {
+			t.Errorf("%v: expected team_id %s, got %q", cfg, fakeTeamID, got)
+		}
+		members := convertSchemaSetToStringSlice(d.Get("members").(*schema.Set))
+		sort.Strings(members)
+		if want := []string{"U001", "U002"}; !reflect.DeepEqual(members, want) {
+			t.Errorf("%v: expected members %v, got %v", cfg, want, members)
+		}
+		if got := convertSchemaSetToStringSlice(d.Get("channels").(*schema.Set)); !reflect.DeepEqual(got, []string{"C001"}) {
+			t.Errorf("%v: expected channels [C001], got %v", cfg, got)
+		}
+		if got := d.Get("date_create").(int); got != 1700000000 {
+			t.Errorf("%v: expected date_create 1700000000, got %d", cfg, got)
+		}
+	}
+
+	// The usergroup index is shared by all lookups
+	if calls := fake.callCount("usergroups.list"); calls != 1 {
+		t.Errorf("expected 1 usergroups.list call, got %d", calls)
+	}
+}
+
+func TestDataSourceSlackUsergroupRead_notFound(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUsergroup("devs")
+
+	d := schema.TestResourceDataRaw(t, dataSourceSlackUsergroup().Schema, map[string]interface{}{
+		"handle": "missing",
+	})
+	diags := dataSourceSlackUsergroupRead(context.Background(), d, fake.meta())
+	if !diags.HasError() {
+		t.Fatalf("expected an error for an unknown handle")
+	}
+	if !strings.Contains(diags[0].Summary, "missing") {
+		t.Errorf("expected the error to name the handle, got %q", diags[0].Summary)
+	}
+}
@@ -0,0 +1,182 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+func dataSourceSlackUsergroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSlackUsergroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"handle_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter usergroups whose handles start with this prefix.",
+			},
+			"include_disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include disabled usergroups in the result.",
+			},
+			"include_users": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Return the member IDs of each usergroup.",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return usergroups of this team. Required by Slack for org-wide tokens.",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Maximum number of usergroups to return.",
+			},
+			"usergroups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"handle": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"team_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_external": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"auto_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"date_create": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"channels": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"members": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Member IDs. Only set when include_users is true.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSlackUsergroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := checkDataSourceScopes(meta, "slack_usergroups"); diags != nil {
+		return diags
+	}
+
+	m := meta.(*providerMeta)
+	var diags diag.Diagnostics
+
+	var allUsergroups []map[string]interface{}
+	prefix := d.Get("handle_prefix").(string)
+	includeDisabled := d.Get("include_disabled").(bool)
+	includeUsers := d.Get("include_users").(bool)
+	teamID := d.Get("team_id").(string)
+	limit := 0
+	if v, ok := d.GetOk("limit"); ok {
+		limit = v.(int)
+	}
+
+	// The cached index has no members and covers the token's own team only
+	var usergroups []slack.UserGroup
+	var err error
+	if includeUsers || teamID != "" {
+		options := []slack.GetUserGroupsOption{
+			slack.GetUserGroupsOptionIncludeDisabled(includeDisabled),
+			slack.GetUserGroupsOptionIncludeUsers(includeUsers),
+		}
+		if teamID != "" {
+			options = append(options, slack.GetUserGroupsOptionWithTeamID(teamID))
+		}
+		usergroups, err = m.client.GetUserGroupsContext(ctx, options...)
+	} else {
+		usergroups, err = m.cache.Usergroups(ctx)
+	}
+	if err != nil {
+		return slackDiagErrorf(err, "usergroups.list", "failed to list Slack usergroups")
+	}
+
+	for _, ug := range usergroups {
+		enabled := ug.DateDelete == 0
+		if !includeDisabled && !enabled {
+			continue
+		}
+		if prefix != "" && !strings.HasPrefix(ug.Handle, prefix) {
+			continue
+		}
+		if teamID != "" && ug.TeamID != teamID {
+			continue
+		}
+
+		var members []string
+		if includeUsers {
+			members = ug.Users
+		}
+		allUsergroups = append(allUsergroups, map[string]interface{}{
+			"id":          ug.ID,
+			"handle":      ug.Handle,
+			"name":        ug.Name,
+			"description": ug.Description,
+			"team_id":     ug.TeamID,
+			"enabled":     enabled,
+			"is_external": ug.IsExternal,
+			"auto_type":   ug.AutoType,
+			"date_create": int(ug.DateCreate),
+			"channels":    ug.Prefs.Channels,
+			"members":     members,
+		})
+
+		if limit > 0 && len(allUsergroups) >= limit {
+			break
+		}
+	}
+
+	if err := d.Set("usergroups", allUsergroups); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("usergroups-%d", len(allUsergroups)))
+
+	return diags
+}
@@ -0,0 +1,58 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSlackUsergroupsRead(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUsergroup("eng-backend", "U001")
+	fake.addUsergroup("eng-frontend", "U001")
+	disabled := fake.addUsergroup("eng-legacy", "U001")
+	fake.addUsergroup("sales", "U001")
+	fake.mu.Lock()
+	fake.usergroups[disabled].DateDelete = 1
+	fake.mu.Unlock()
+	ctx := context.Background()
+	meta := fake.meta()
+
+	cases := []struct {
+		name        string
+		cfg         map[string]interface{}
+		wantHandles []string
+		wantMembers bool
+	}{
+		{"all", map[string]interface{}{}, []string{"eng-backend", "eng-frontend", "sales"}, false},
+		{"prefix", map[string]interface{}{"handle_prefix": "eng-"}, []string{"eng-backend", "eng-frontend"}, false},
+		{"disabled", map[string]interface{}{"handle_prefix": "eng-", "include_disabled": true}, []string{"eng-backend", "eng-frontend", "eng-legacy"}, false},
+		{"limit", map[string]interface{}{"limit": 1}, []string{"eng-backend"}, false},
+		{"users", map[string]interface{}{"handle_prefix": "sales", "include_users": true}, []string{"sales"}, true},
+		{"team", map[string]interface{}{"team_id": "T_OTHER"}, nil, false},
+	}
+	for _, tt := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourceSlackUsergroups().Schema, tt.cfg)
+		if diags := dataSourceSlackUsergroupsRead(ctx, d, meta); diags.HasError() {
+			t.Fatalf("%s: unexpected error: %v", tt.name, diags)
+		}
+		usergroups := d.Get("usergroups").([]interface{})
+		if len(usergroups) != len(tt.wantHandles) {
+			t.Fatalf("%s: expected %d usergroups, got %d", tt.name, len(tt.wantHandles), len(usergroups))
+		}
+		for i, raw := range usergroups {
+			ug := raw.(map[string]interface{})
+			if ug["handle"] != tt.wantHandles[i] {
+				t.Errorf("%s: expected usergroup %d to be %s, got %v", tt.name, i, tt.wantHandles[i], ug["handle"])
+			}
+			if members := ug["members"].([]interface{}); (len(members) > 0) != tt.wantMembers {
+				t.Errorf("%s: unexpected members %v for %v", tt.name, members, ug["handle"])
+			}
+			if enabled := ug["enabled"].(bool); enabled != (ug["handle"] != "eng-legacy") {
+				t.Errorf("%s: unexpected enabled %v for %v", tt.name, enabled, ug["handle"])
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/slack-go/slack"
+)
+
+// slackErrorKind is a coarse category of Slack API failures, used to give
+// consistent diagnostics regardless of which method failed.
+type slackErrorKind int
+
+const (
+	slackErrorUnknown slackErrorKind = iota
+	slackErrorNotFound
+	slackErrorPermission
+	slackErrorRateLimited
+	slackErrorAlreadyExists
+	slackErrorInvalidArgument
+)
+
+// slackErrorKinds maps Slack error codes to their category.
+var slackErrorKinds = map[string]slackErrorKind{
+	// Not found
+	"channel_not_found": slackErrorNotFound,
+	"user_not_found":    slackErrorNotFound,
+	"users_not_found":   slackErrorNotFound,
+	"no_such_subteam":   slackErrorNotFound,
+	"subteam_not_found": slackErrorNotFound,
+
+	// Permission / scope
+	"missing_scope":          slackErrorPermission,
+	"not_authed":             slackErrorPermission,
+	"invalid_auth":           slackErrorPermission,
+	"account_inactive":       slackErrorPermission,
+	"token_revoked":          slackErrorPermission,
+	"token_expired":          slackErrorPermission,
+	"not_allowed_token_type": slackErrorPermission,
+	"no_permission":          slackErrorPermission,
+	"restricted_action":      slackErrorPermission,
+	"not_in_channel":         slackErrorPermission,
+	"cant_kick_self":         slackErrorPermission,
+	"cant_kick_from_general": slackErrorPermission,
+	"permission_denied":      slackErrorPermission,
+	"ekm_access_denied":      slackErrorPermission,
+
+	// Rate limited
+	"ratelimited": slackErrorRateLimited,
+
+	// Already exists
+	"name_taken":            slackErrorAlreadyExists,
+	"name_already_exists":   slackErrorAlreadyExists,
+	"handle_already_exists": slackErrorAlreadyExists,
+	"already_in_channel":    slackErrorAlreadyExists,
+	"already_archived":      slackErrorAlreadyExists,
+
+	// Invalid argument
+	"invalid_arguments":        slackErrorInvalidArgument,
+	"invalid_arg_name":         slackErrorInvalidArgument,
+	"invalid_name":             slackErrorInvalidArgument,
+	"invalid_name_maxlength":   slackErrorInvalidArgument,
+	"invalid_name_punctuation": slackErrorInvalidArgument,
+	"invalid_name_required":    slackErrorInvalidArgument,
+	"invalid_name_specials":    slackErrorInvalidArgument,
+	"invalid_users":            slackErrorInvalidArgument,
+	"no_users_provided":        slackErrorInvalidArgument,
+	"is_archived":              slackErrorInvalidArgument,
+	"not_archived":             slackErrorInvalidArgument,
+	"too_long":                 slackErrorInvalidArgument,
+}
+
+// slackMethodScopes lists the OAuth scopes accepted by each Slack API method
+// the provider calls. Any one of the listed scopes is sufficient.
+var slackMethodScopes = map[string][]string{
+	"conversations.list":       {"channels:read", "groups:read"},
+	"conversations.info":       {"channels:read", "groups:read"},
+	"conversations.members":    {"channels:read", "groups:read"},
+	"conversations.create":     {"channels:manage", "groups:write"},
+	"conversations.rename":     {"channels:manage", "groups:write"},
+	"conversations.setTopic":   {"channels:manage", "groups:write"},
+	"conversations.setPurpose": {"channels:manage", "groups:write"},
+	"conversations.invite":     {"channels:manage", "groups:write"},
+	"conversations.kick":       {"channels:manage", "channels:write", "groups:write"},
+	"conversations.join":       {"channels:join"},
+	"conversations.archive":    {"channels:manage", "groups:write"},
+	"conversations.unarchive":  {"channels:manage", "groups:write"},
+	"users.info":               {"users:read"},
+	"users.list":               {"users:read"},
+	"users.lookupByEmail":      {"users:read.email"},
+	"usergroups.create":        {"usergroups:write"},
+	"usergroups.list":          {"usergroups:read"},
+	"usergroups.update":        {"usergroups:write"},
+	"usergroups.users.list":    {"usergroups:read"},
+	"usergroups.users.update":  {"usergroups:write"},
+	"usergroups.disable":       {"usergroups:write"},
+	"usergroups.enable":        {"usergroups:write"},
+
+	"admin.conversations.convertToPrivate": {"admin.conversations:write"},
+	"admin.conversations.convertToPublic":  {"admin.conversations:write"},
+	"admin.conversations.delete":           {"admin.conversations:write"},
+}
+
+// slackErrorCode returns the Slack error code carried by err (for example
+// "channel_not_found"), or an empty string if err is not a Slack API error.
+func slackErrorCode(err error) string {
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		return slackErr.Err
+	}
+	return ""
+}
+
+// classifySlackError maps err to a slackErrorKind.
+func classifySlackError(err error) slackErrorKind {
+	if err == nil {
+		return slackErrorUnknown
+	}
+
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return slackErrorRateLimited
+	}
+
+	var statusErr slack.StatusCodeError
+	if errors.As(err, &statusErr) {
+		switch statusErr.Code {
+		case 401, 403:
+			return slackErrorPermission
+		case 404:
+			return slackErrorNotFound
+		case 429:
+			return slackErrorRateLimited
+		}
+		return slackErrorUnknown
+	}
+
+	return slackErrorKinds[slackErrorCode(err)]
+}
+
+// isSlackNotFound reports whether err means the requested object does not exist.
+func isSlackNotFound(err error) bool {
+	return classifySlackError(err) == slackErrorNotFound
+}
+
+// slackErrorDetail explains a failed call to the Slack API method in terms
+// of its error category, including the required OAuth scopes when the token
+// lacks them.
+func slackErrorDetail(err error, method string) string {
+	code := slackErrorCode(err)
+
+	switch classifySlackError(err) {
+	case slackErrorNotFound:
+		return fmt.Sprintf("Slack reported that the object does not exist or is not visible to this token (%s).", code)
+	case slackErrorPermission:
+		if code == "missing_scope" {
+			if scopes, ok := slackMethodScopes[method]; ok {
+				return fmt.Sprintf("The Slack token is missing the OAuth scope required by %s. Add one of the following scopes to your Slack app and reinstall it: %s.", method, strings.Join(scopes, " or "))
+			}
+			return fmt.Sprintf("The Slack token is missing the OAuth scope required by %s.", method)
+		}
+		return fmt.Sprintf("The Slack token is not allowed to call %s (%s).", method, err)
+	case slackErrorRateLimited:
+		return "Slack kept rate limiting the request after all retries. Consider increasing the provider's 'max_retries' or 'retry_timeout'."
+	case slackErrorAlreadyExists:
+		return fmt.Sprintf("Slack reported that the object already exists or is already in the requested state (%s).", code)
+	case slackErrorInvalidArgument:
+		return fmt.Sprintf("Slack rejected the arguments sent to %s (%s).", method, code)
+	}
+	return ""
+}
+
+// slackDiagErrorf returns an error diagnostic for a failed call to the
+// Slack API method. The summary is built from format and args followed by
+// the error; the detail depends on the error category.
+func slackDiagErrorf(err error, method string, format string, args ...interface{}) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s: %s", fmt.Sprintf(format, args...), err),
+			Detail:   slackErrorDetail(err, method),
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestClassifySlackError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want slackErrorKind
+	}{
+		{"nil", nil, slackErrorUnknown},
+		{"not found", slack.SlackErrorResponse{Err: "channel_not_found"}, slackErrorNotFound},
+		{"wrapped not found", fmt.Errorf("listing: %w", slack.SlackErrorResponse{Err: "channel_not_found"}), slackErrorNotFound},
+		{"missing scope", slack.SlackErrorResponse{Err: "missing_scope"}, slackErrorPermission},
+		{"rate limited", &slack.RateLimitedError{}, slackErrorRateLimited},
+		{"rate limited code", slack.SlackErrorResponse{Err: "ratelimited"}, slackErrorRateLimited},
+		{"already exists", slack.SlackErrorResponse{Err: "name_taken"}, slackErrorAlreadyExists},
+		{"invalid argument", slack.SlackErrorResponse{Err: "invalid_name_specials"}, slackErrorInvalidArgument},
+		{"forbidden", slack.StatusCodeError{Code: http.StatusForbidden}, slackErrorPermission},
+		{"unknown code", slack.SlackErrorResponse{Err: "something_new"}, slackErrorUnknown},
+		{"plain error", fmt.Errorf("boom"), slackErrorUnknown},
+	}
+	for _, tt := range cases {
+		if got := classifySlackError(tt.err); got != tt.want {
+			t.Errorf("%s: expected kind %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSlackDiagErrorf_missingScope(t *testing.T) {
+	diags := slackDiagErrorf(slack.SlackErrorResponse{Err: "missing_scope"}, "usergroups.create", "error creating usergroup")
+	if !diags.HasError() {
+		t.Fatal("expected an error diagnostic")
+	}
+	if got := diags[0].Summary; got != "error creating usergroup: missing_scope" {
+		t.Errorf("unexpected summary: %s", got)
+	}
+	if !strings.Contains(diags[0].Detail, "usergroups:write") {
+		t.Errorf("expected detail to name the missing scope, got: %s", diags[0].Detail)
+	}
+}
@@ -0,0 +1,625 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+const (
+	fakeTeamID    = "T0000000001"
+	fakeBotUserID = "UBOT000001"
+)
+
+// fakeSlack is an in-process stand-in for the Slack Web API. It keeps
+// channels, users and usergroups in memory and implements the methods the
+// provider calls, so CRUD functions and resource.UnitTest runs can execute
+// offline.
+type fakeSlack struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu       sync.Mutex
+	nextID   int
+	pageSize int
+	calls    map[string]int
+	scopes   []string
+	// tokenScopes overrides scopes for individual tokens.
+	tokenScopes map[string][]string
+	tokens      map[string]string
+	callTokens  map[string][]string
+	channels    map[string]*fakeChannel
+	users       map[string]*slack.User
+	usergroups  map[string]*slack.UserGroup
+}
+
+type fakeChannel struct {
+	slack.Channel
+	members []string
+}
+
+func newFakeSlack(t *testing.T) *fakeSlack {
+	t.Helper()
+
+	f := &fakeSlack{
+		t:           t,
+		calls:       map[string]int{},
+		tokenScopes: map[string][]string{},
+		tokens:      map[string]string{},
+		callTokens:  map[string][]string{},
+		channels:    map[string]*fakeChannel{},
+		users:       map[string]*slack.User{},
+		usergroups:  map[string]*slack.UserGroup{},
+	}
+	f.addUser(fakeBotUserID, "terraform", "")
+	f.users[fakeBotUserID].IsBot = true
+
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// client returns a slack-go client that talks to the fake server.
+func (f *fakeSlack) client() *slack.Client {
+	return f.clientWithToken("xoxb-fake")
+}
+
+// clientWithToken returns a client for the fake server that authenticates
+// with token. Tokens not registered with addToken act as the bot.
+func (f *fakeSlack) clientWithToken(token string) *slack.Client {
+	return slack.New(token, slack.OptionAPIURL(f.server.URL+"/"))
+}
+
+// adminMeta returns meta whose admin token authenticates as userID.
+func (f *fakeSlack) adminMeta(userID string) *providerMeta {
+	f.addToken("xoxp-admin", userID)
+	m := f.meta()
+	m.adminClient = f.clientWithToken("xoxp-admin")
+	m.admin = newAdminAPIClient(f.server.Client(), f.server.URL+"/", "xoxp-admin")
+	return m
+}
+
+// addToken makes token authenticate as userID.
+func (f *fakeSlack) addToken(token, userID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[token] = userID
+}
+
+// meta returns provider meta wrapping a client for the fake server, as
+// passed to CRUD functions by the provider.
+func (f *fakeSlack) meta() *providerMeta {
+	return newProviderMeta(f.client())
+}
+
+// providerFactories returns provider factories whose configured client
+// points at the fake server, for use with resource.UnitTest.
+func (f *fakeSlack) providerFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"slack": func() (*schema.Provider, error) {
+			p := Provider()
+			p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				return f.meta(), nil
+			}
+			return p, nil
+		},
+	}
+}
+
+// skipWithoutTerraform skips tests that drive the Terraform CLI when no
+// binary is available, instead of letting the test framework download one.
+// In CI the binary is installed, so a missing one fails the test instead.
+func skipWithoutTerraform(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		if os.Getenv("CI") != "" {
+			t.Fatal("terraform CLI not found; CI must install it so that these tests run")
+		}
+		t.Skip("terraform CLI not found; set TF_ACC_TERRAFORM_PATH to run this test")
+	}
+}
+
+func (f *fakeSlack) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s%09d", prefix, f.nextID)
+}
+
+func (f *fakeSlack) addUser(id, name, email string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.users[id] = &slack.User{
+		ID:       id,
+		TeamID:   fakeTeamID,
+		Name:     name,
+		RealName: name,
+		Profile:  slack.UserProfile{Email: email, DisplayName: name},
+	}
+}
+
+func (f *fakeSlack) addChannel(name string, isPrivate bool, members ...string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.newID("C")
+	ch := &fakeChannel{members: append([]string{}, members...)}
+	ch.ID = id
+	ch.Name = name
+	ch.IsPrivate = isPrivate
+	f.channels[id] = ch
+	return id
+}
+
+func (f *fakeSlack) addUsergroup(handle string, members ...string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.newID("S")
+	f.usergroups[id] = &slack.UserGroup{
+		ID:          id,
+		TeamID:      fakeTeamID,
+		IsUserGroup: true,
+		Name:        handle,
+		Handle:      handle,
+		Users:       append([]string{}, members...),
+		UserCount:   len(members),
+	}
+	return id
+}
+
+func (f *fakeSlack) channel(id string) *fakeChannel {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.channels[id]
+}
+
+func (f *fakeSlack) channelMembers(id string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	members := append([]string{}, f.channels[id].members...)
+	sort.Strings(members)
+	return members
+}
+
+func (f *fakeSlack) usergroup(id string) *slack.UserGroup {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.usergroups[id]
+}
+
+func (f *fakeSlack) callCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+// callTokensFor returns the tokens used for each call to method, in order.
+func (f *fakeSlack) callTokensFor(method string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.callTokens[method]...)
+}
+
+func (f *fakeSlack) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	method := strings.TrimPrefix(r.URL.Path, "/")
+	token := r.Form.Get("token")
+	if token == "" {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+
+	f.mu.Lock()
+	f.calls[method]++
+	f.callTokens[method] = append(f.callTokens[method], token)
+	resp, code := f.dispatch(method, token, r.Form)
+	scopes, ok := f.tokenScopes[token]
+	if !ok {
+		scopes = f.scopes
+	}
+	f.mu.Unlock()
+
+	if resp == nil {
+		resp = map[string]interface{}{}
+	}
+	resp["ok"] = code == ""
+	if code != "" {
+		resp["error"] = code
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if scopes != nil {
+		w.Header().Set("X-OAuth-Scopes", strings.Join(scopes, ","))
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		f.t.Errorf("fake slack: encoding %s response: %s", method, err)
+	}
+}
+
+type fakeResponse map[string]interface{}
+
+// dispatch handles one Web API method. It returns the response body and a
+// Slack error code, which is empty on success. Callers hold f.mu.
+func (f *fakeSlack) dispatch(method, token string, form map[string][]string) (fakeResponse, string) {
+	get := func(key string) string {
+		if v := form[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	switch method {
+	case "auth.test":
+		userID, ok := f.tokens[token]
+		if !ok {
+			return fakeResponse{"user_id": fakeBotUserID, "user": "terraform", "team_id": fakeTeamID}, ""
+		}
+		return fakeResponse{"user_id": userID, "user": f.users[userID].Name, "team_id": fakeTeamID}, ""
+
+	// Conversations
+	case "conversations.list":
+		types := get("types")
+		excludeArchived := get("exclude_archived") == "true"
+		var all []slack.Channel
+		for _, ch := range f.sortedChannels() {
+			if excludeArchived && ch.IsArchived {
+				continue
+			}
+			if ch.IsPrivate && types != "" && !strings.Contains(types, "private_channel") {
+				continue
+			}
+			if !ch.IsPrivate && types != "" && !strings.Contains(types, "public_channel") {
+				continue
+			}
+			all = append(all, ch.Channel)
+		}
+		start, end, next := f.page(len(all), get("cursor"), get("limit"))
+		return fakeResponse{
+			"channels":          all[start:end],
+			"response_metadata": map[string]string{"next_cursor": next},
+		}, ""
+
+	case "conversations.info":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		return fakeResponse{"channel": ch.Channel}, ""
+
+	case "conversations.members":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		start, end, next := f.page(len(ch.members), get("cursor"), get("limit"))
+		return fakeResponse{
+			"members":           ch.members[start:end],
+			"response_metadata": map[string]string{"next_cursor": next},
+		}, ""
+
+	case "conversations.create":
+		name := get("name")
+		for _, ch := range f.channels {
+			if ch.Name == name {
+				return nil, "name_taken"
+			}
+		}
+		ch := &fakeChannel{members: []string{fakeBotUserID}}
+		ch.ID = f.newID("C")
+		ch.Name = name
+		ch.IsPrivate = get("is_private") == "true"
+		f.channels[ch.ID] = ch
+		return fakeResponse{"channel": ch.Channel}, ""
+
+	case "conversations.rename", "conversations.setTopic", "conversations.setPurpose":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		if ch.IsArchived {
+			return nil, "is_archived"
+		}
+		switch method {
+		case "conversations.rename":
+			ch.Name = get("name")
+		case "conversations.setTopic":
+			ch.Topic.Value = get("topic")
+		case "conversations.setPurpose":
+			ch.Purpose.Value = get("purpose")
+		}
+		return fakeResponse{"channel": ch.Channel}, ""
+
+	case "conversations.invite":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		added := false
+		for _, user := range strings.Split(get("users"), ",") {
+			if _, ok := f.users[user]; !ok {
+				return nil, "user_not_found"
+			}
+			if !containsString(ch.members, user) {
+				ch.members = append(ch.members, user)
+				added = true
+			}
+		}
+		if !added {
+			return nil, "already_in_channel"
+		}
+		return fakeResponse{"channel": ch.Channel}, ""
+
+	case "conversations.kick":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		// The caller must be in the channel and cannot kick itself
+		caller := fakeBotUserID
+		if userID, ok := f.tokens[token]; ok {
+			caller = userID
+		}
+		user := get("user")
+		if user == caller {
+			return nil, "cant_kick_self"
+		}
+		if !containsString(ch.members, caller) || !containsString(ch.members, user) {
+			return nil, "not_in_channel"
+		}
+		ch.members = removeString(ch.members, user)
+		return nil, ""
+
+	case "conversations.join":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		if ch.IsArchived {
+			return nil, "is_archived"
+		}
+		if !containsString(ch.members, fakeBotUserID) {
+			ch.members = append(ch.members, fakeBotUserID)
+		}
+		return fakeResponse{"channel": ch.Channel}, ""
+
+	case "conversations.archive":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		if ch.IsArchived {
+			return nil, "already_archived"
+		}
+		if !containsString(ch.members, fakeBotUserID) {
+			return nil, "not_in_channel"
+		}
+		ch.IsArchived = true
+		return nil, ""
+
+	case "conversations.unarchive":
+		ch, ok := f.channels[get("channel")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		if !ch.IsArchived {
+			return nil, "not_archived"
+		}
+		ch.IsArchived = false
+		return nil, ""
+
+	// Admin (user tokens only)
+	case "admin.conversations.convertToPrivate", "admin.conversations.convertToPublic":
+		if _, ok := f.tokens[token]; !ok {
+			return nil, "not_allowed_token_type"
+		}
+		ch, ok := f.channels[get("channel_id")]
+		if !ok {
+			return nil, "channel_not_found"
+		}
+		ch.IsPrivate = method == "admin.conversations.convertToPrivate"
+		return nil, ""
+
+	case "admin.conversations.delete":
+		if _, ok := f.tokens[token]; !ok {
+			return nil, "not_allowed_token_type"
+		}
+		if _, ok := f.channels[get("channel_id")]; !ok {
+			return nil, "channel_not_found"
+		}
+		delete(f.channels, get("channel_id"))
+		return nil, ""
+
+	// Users
+	case "users.info":
+		user, ok := f.users[get("user")]
+		if !ok {
+			return nil, "user_not_found"
+		}
+		return fakeResponse{"user": user}, ""
+
+	case "users.lookupByEmail":
+		for _, user := range f.users {
+			if user.Profile.Email != "" && user.Profile.Email == get("email") {
+				return fakeResponse{"user": user}, ""
+			}
+		}
+		return nil, "users_not_found"
+
+	case "users.list":
+		ids := make([]string, 0, len(f.users))
+		for id := range f.users {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		start, end, next := f.page(len(ids), get("cursor"), get("limit"))
+		members := make([]*slack.User, 0, end-start)
+		for _, id := range ids[start:end] {
+			members = append(members, f.users[id])
+		}
+		return fakeResponse{
+			"members":           members,
+			"response_metadata": map[string]string{"next_cursor": next},
+		}, ""
+
+	// Usergroups
+	case "usergroups.create":
+		for _, ug := range f.usergroups {
+			if ug.Handle == get("handle") || ug.Name == get("name") {
+				return nil, "name_already_exists"
+			}
+		}
+		ug := &slack.UserGroup{
+			ID:          f.newID("S"),
+			TeamID:      fakeTeamID,
+			IsUserGroup: true,
+			Name:        get("name"),
+			Handle:      get("handle"),
+			Description: get("description"),
+			Users:       []string{},
+		}
+		if channels := get("channels"); channels != "" {
+			ug.Prefs.Channels = strings.Split(channels, ",")
+		}
+		f.usergroups[ug.ID] = ug
+		return fakeResponse{"usergroup": ug}, ""
+
+	case "usergroups.list":
+		includeDisabled := get("include_disabled") == "true"
+		includeUsers := get("include_users") == "true"
+		ids := make([]string, 0, len(f.usergroups))
+		for id := range f.usergroups {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		groups := make([]slack.UserGroup, 0, len(ids))
+		for _, id := range ids {
+			ug := *f.usergroups[id]
+			if ug.DateDelete != 0 && !includeDisabled {
+				continue
+			}
+			if !includeUsers {
+				ug.Users = nil
+			}
+			groups = append(groups, ug)
+		}
+		return fakeResponse{"usergroups": groups}, ""
+
+	case "usergroups.update":
+		ug, ok := f.usergroups[get("usergroup")]
+		if !ok {
+			return nil, "no_such_subteam"
+		}
+		if v := get("name"); v != "" {
+			ug.Name = v
+		}
+		if v := get("handle"); v != "" {
+			ug.Handle = v
+		}
+		if v, ok := form["description"]; ok {
+			ug.Description = v[0]
+		}
+		if v, ok := form["channels"]; ok {
+			ug.Prefs.Channels = nil
+			if v[0] != "" {
+				ug.Prefs.Channels = strings.Split(v[0], ",")
+			}
+		}
+		return fakeResponse{"usergroup": ug}, ""
+
+	case "usergroups.users.list":
+		ug, ok := f.usergroups[get("usergroup")]
+		if !ok {
+			return nil, "no_such_subteam"
+		}
+		return fakeResponse{"users": ug.Users}, ""
+
+	case "usergroups.users.update":
+		ug, ok := f.usergroups[get("usergroup")]
+		if !ok {
+			return nil, "no_such_subteam"
+		}
+		if get("users") == "" {
+			return nil, "no_users_provided"
+		}
+		ug.Users = strings.Split(get("users"), ",")
+		ug.UserCount = len(ug.Users)
+		return fakeResponse{"usergroup": ug}, ""
+
+	case "usergroups.disable", "usergroups.enable":
+		ug, ok := f.usergroups[get("usergroup")]
+		if !ok {
+			return nil, "no_such_subteam"
+		}
+		if method == "usergroups.disable" {
+			ug.DateDelete = 1
+		} else {
+			ug.DateDelete = 0
+		}
+		return fakeResponse{"usergroup": ug}, ""
+	}
+
+	return nil, "unknown_method"
+}
+
+func (f *fakeSlack) sortedChannels() []*fakeChannel {
+	ids := make([]string, 0, len(f.channels))
+	for id := range f.channels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	channels := make([]*fakeChannel, 0, len(ids))
+	for _, id := range ids {
+		channels = append(channels, f.channels[id])
+	}
+	return channels
+}
+
+// page slices a result set of length n using an offset cursor. pageSize,
+// when set, overrides the requested limit so tests can force pagination.
+func (f *fakeSlack) page(n int, cursor, limit string) (int, int, string) {
+	start, _ := strconv.Atoi(cursor)
+	size, _ := strconv.Atoi(limit)
+	if f.pageSize > 0 {
+		size = f.pageSize
+	}
+	if size <= 0 || start+size >= n {
+		if start > n {
+			start = n
+		}
+		return start, n, ""
+	}
+	return start, start + size, strconv.Itoa(start + size)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(list []string, s string) []string {
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if v != s {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -1,6 +1,10 @@
 package slack
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -21,3 +25,66 @@ func convertStringSliceToInterface(slice []string) []interface{} {
 	}
 	return result
 }
+
+// removeMember returns members without id.
+func removeMember(members []string, id string) []string {
+	result := make([]string, 0, len(members))
+	for _, m := range members {
+		if m != id {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// memberID returns the "<parent>:<user>" ID of a membership resource such as
+// slack_channel_member or slack_usergroup_member.
+func memberID(parentID, userID string) string {
+	return parentID + ":" + userID
+}
+
+// parseMemberID splits a membership resource ID built by memberID. resource
+// and parent name the resource type and the first part of the ID in the
+// error.
+func parseMemberID(resource, parent, id string) (parentID, userID string, err error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid %s ID %q, expected <%s>:<user_id>", resource, id, parent)
+	}
+	return parts[0], parts[1], nil
+}
+
+// keyedMutex serializes work per key, e.g. per usergroup, across the
+// resources of one provider instance.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*sync.Mutex)}
+}
+
+// Lock locks key and returns the function that unlocks it.
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		k.locks[key] = l
+	}
+	k.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// hasMember reports whether id is in members.
+func hasMember(members []string, id string) bool {
+	for _, m := range members {
+		if m == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package slack
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const defaultRequestTimeout = 30 * time.Second
+
+// httpConfig holds the provider's HTTP transport settings.
+type httpConfig struct {
+	proxyURL           string
+	caCertFile         string
+	caCertPEM          string
+	insecureSkipVerify bool
+	timeout            time.Duration
+	userAgent          string
+}
+
+// newHTTPClient builds the HTTP client used for every Slack API call.
+// Without an explicit proxy URL the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+// environment variables apply. A custom CA bundle is added to the system
+// roots rather than replacing them.
+func newHTTPClient(cfg httpConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.proxyURL != "" {
+		proxy, err := url.Parse(cfg.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http_proxy %q: %w", cfg.proxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// #nosec G402 -- opt-in for TLS-intercepting proxies
+		InsecureSkipVerify: cfg.insecureSkipVerify,
+	}
+
+	caPEM := []byte(cfg.caCertPEM)
+	if cfg.caCertFile != "" {
+		data, err := os.ReadFile(cfg.caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ca_cert_file: %w", err)
+		}
+		caPEM = data
+	}
+	if len(caPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no PEM certificates found in the configured CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Timeout: cfg.timeout,
+		Transport: &userAgentTransport{
+			base:      transport,
+			userAgent: cfg.userAgent,
+		},
+	}, nil
+}
+
+// userAgentTransport sets the User-Agent header on every request.
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent == "" {
+		return t.base.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
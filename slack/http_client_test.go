@@ -0,0 +1,52 @@
+package slack
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClient_caAndUserAgent(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// Without the CA the server certificate is not trusted
+	client, err := newHTTPClient(httpConfig{timeout: time.Second})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("expected a TLS verification error without the custom CA")
+	}
+
+	client, err = newHTTPClient(httpConfig{
+		caCertPEM: string(caPEM),
+		timeout:   time.Second,
+		userAgent: "terraform-provider-slack/test",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error with custom CA: %s", err)
+	}
+	resp.Body.Close()
+
+	if gotUserAgent != "terraform-provider-slack/test" {
+		t.Errorf("expected custom User-Agent, got %q", gotUserAgent)
+	}
+}
+
+func TestNewHTTPClient_invalidCA(t *testing.T) {
+	if _, err := newHTTPClient(httpConfig{caCertPEM: "not a certificate"}); err == nil {
+		t.Fatal("expected an error for an invalid CA bundle")
+	}
+}
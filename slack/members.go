@@ -0,0 +1,251 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// memberEmailsSchema is the member_emails argument shared by slack_channel
+// and slack_usergroup.
+func memberEmailsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Set:         schema.HashString,
+		Description: description,
+	}
+}
+
+// resolveMemberEmails maps each address to the ID of the active Slack user
+// it belongs to, using the cached user directory. Addresses without an
+// active user are returned in unknown, sorted.
+func resolveMemberEmails(ctx context.Context, cache *workspaceCache, emails []string) (ids map[string]string, unknown []string, err error) {
+	ids = make(map[string]string, len(emails))
+	for _, email := range emails {
+		user, err := cache.UserByEmail(ctx, email)
+		if err != nil {
+			return nil, nil, err
+		}
+		if user == nil || user.Deleted {
+			unknown = append(unknown, email)
+			continue
+		}
+		ids[email] = user.ID
+	}
+	sort.Strings(unknown)
+	return ids, unknown, nil
+}
+
+// validateMemberEmailsDiff fails the plan when member_emails contains an
+// address that does not belong to an active Slack user.
+func validateMemberEmailsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	m, ok := meta.(*providerMeta)
+	if !ok || !d.NewValueKnown("member_emails") {
+		return nil
+	}
+	emails := convertSchemaSetToStringSlice(d.Get("member_emails").(*schema.Set))
+	if len(emails) == 0 {
+		return nil
+	}
+
+	_, unknown, err := resolveMemberEmails(ctx, m.cache, emails)
+	if err != nil {
+		return fmt.Errorf("error resolving member_emails: %w", err)
+	}
+	if len(unknown) > 0 {
+		return cty.GetAttrPath("member_emails").NewErrorf("no active Slack user found for %s (reading email addresses requires the users:read.email scope)", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// desiredMembers returns the union of members and the users behind
+// member_emails. Unknown addresses are an error.
+func desiredMembers(ctx context.Context, d *schema.ResourceData, cache *workspaceCache) ([]string, error) {
+	members := convertSchemaSetToStringSlice(d.Get("members").(*schema.Set))
+	emails := convertSchemaSetToStringSlice(d.Get("member_emails").(*schema.Set))
+	if len(emails) == 0 {
+		return members, nil
+	}
+
+	ids, unknown, err := resolveMemberEmails(ctx, cache, emails)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving member_emails: %w", err)
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("no active Slack user found for member_emails %s", strings.Join(unknown, ", "))
+	}
+
+	seen := make(map[string]bool, len(members))
+	for _, id := range members {
+		seen[id] = true
+	}
+	for _, email := range emails {
+		if id := ids[email]; !seen[id] {
+			seen[id] = true
+			members = append(members, id)
+		}
+	}
+	return members, nil
+}
+
+// readMemberEmails sets member_emails to the declared addresses whose users
+// are in actual, so addresses of users who were removed show up as drift.
+// It returns the IDs of the users declared only through member_emails.
+func readMemberEmails(ctx context.Context, d *schema.ResourceData, cache *workspaceCache, actual []string) (map[string]bool, error) {
+	emails := convertSchemaSetToStringSlice(d.Get("member_emails").(*schema.Set))
+	if len(emails) == 0 {
+		return nil, nil
+	}
+
+	ids, _, err := resolveMemberEmails(ctx, cache, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	actualSet := make(map[string]bool, len(actual))
+	for _, id := range actual {
+		actualSet[id] = true
+	}
+	declared := d.Get("members").(*schema.Set)
+
+	present := make([]string, 0, len(emails))
+	emailOnly := make(map[string]bool, len(ids))
+	for _, email := range emails {
+		id, ok := ids[email]
+		if !ok {
+			continue
+		}
+		if actualSet[id] {
+			present = append(present, email)
+		}
+		if !declared.Contains(id) {
+			emailOnly[id] = true
+		}
+	}
+
+	if err := d.Set("member_emails", present); err != nil {
+		return nil, err
+	}
+	return emailOnly, nil
+}
+
+// usergroupMembers returns the current members of each usergroup in ids
+// that can join a channel.
+func usergroupMembers(ctx context.Context, cache *workspaceCache, ids []string) (map[string][]string, error) {
+	members := make(map[string][]string, len(ids))
+	for _, id := range ids {
+		users, err := cache.UsergroupMembers(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error listing members of usergroup %s: %w", id, err)
+		}
+		for _, user := range users {
+			ok, err := canJoinChannels(ctx, cache, user)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				members[id] = append(members[id], user)
+			}
+		}
+	}
+	return members, nil
+}
+
+// canJoinChannels reports whether a usergroup member is added to channels
+// through member_usergroup_ids. Deactivated users and guests are skipped:
+// they cannot be invited like regular members, and waiting for them would
+// report the usergroup as out of sync on every plan. Users missing from the
+// directory are kept.
+func canJoinChannels(ctx context.Context, cache *workspaceCache, userID string) (bool, error) {
+	user, err := cache.UserByID(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("error reading the Slack user directory: %w", err)
+	}
+	return user == nil || !(user.Deleted || user.IsRestricted || user.IsUltraRestricted), nil
+}
+
+// desiredChannelMembers returns desiredMembers plus the current members of
+// the usergroups in member_usergroup_ids.
+func desiredChannelMembers(ctx context.Context, d *schema.ResourceData, m *providerMeta) ([]string, error) {
+	members, err := desiredMembers(ctx, d, m.cache)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := usergroupMembers(ctx, m.cache, convertSchemaSetToStringSlice(d.Get("member_usergroup_ids").(*schema.Set)))
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(members))
+	for _, id := range members {
+		seen[id] = true
+	}
+	for _, users := range groups {
+		for _, id := range users {
+			if !seen[id] {
+				seen[id] = true
+				members = append(members, id)
+			}
+		}
+	}
+	return members, nil
+}
+
+// readMemberUsergroups keeps in member_usergroup_ids the usergroups whose
+// members are all in actual, so a usergroup that gained members, or no
+// longer exists, shows up as drift. Members that cannot join channels are
+// not waited for. It returns the IDs of the users included only through
+// member_usergroup_ids.
+func readMemberUsergroups(ctx context.Context, d *schema.ResourceData, cache *workspaceCache, actual []string) (map[string]bool, error) {
+	ids := convertSchemaSetToStringSlice(d.Get("member_usergroup_ids").(*schema.Set))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	actualSet := make(map[string]bool, len(actual))
+	for _, id := range actual {
+		actualSet[id] = true
+	}
+	declared := d.Get("members").(*schema.Set)
+
+	inSync := make([]string, 0, len(ids))
+	groupOnly := make(map[string]bool)
+	for _, id := range ids {
+		users, err := cache.UsergroupMembers(ctx, id)
+		if isSlackNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing members of usergroup %s: %w", id, err)
+		}
+
+		complete := true
+		for _, user := range users {
+			if !actualSet[user] {
+				ok, err := canJoinChannels(ctx, cache, user)
+				if err != nil {
+					return nil, err
+				}
+				complete = complete && !ok
+			}
+			if !declared.Contains(user) {
+				groupOnly[user] = true
+			}
+		}
+		if complete {
+			inSync = append(inSync, id)
+		}
+	}
+
+	if err := d.Set("member_usergroup_ids", inSync); err != nil {
+		return nil, err
+	}
+	return groupOnly, nil
+}
@@ -2,61 +2,259 @@ package slack
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/slack-go/slack"
 )
 
+// Version is the provider version reported in the User-Agent header. It is
+// set by main from the version stamped in at build time.
+var Version = "dev"
+
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("SLACK_TOKEN", nil),
-				Description: "Slack API Token (starts with xoxb-)",
+				Deprecated:  "Use bot_token instead.",
+				Description: "Slack API Token (starts with xoxb-). Deprecated alias of bot_token.",
+			},
+			"bot_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SLACK_BOT_TOKEN", nil),
+				Description: "Slack bot token (starts with xoxb-). Used for all calls that do not need a user or admin token.",
+			},
+			"user_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SLACK_USER_TOKEN", nil),
+				Description: "Slack user token (starts with xoxp-). Used for calls that act on behalf of a user, such as removing channel members. Falls back to the bot token when unset.",
+			},
+			"admin_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("SLACK_ADMIN_TOKEN", nil),
+				Description: "Slack user token (starts with xoxp-) of a workspace or org admin. Required for admin.* API methods.",
+			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SLACK_API_URL", slack.APIURL),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Base URL of the Slack Web API. Override it to route calls through a proxy or audit gateway, or to point the provider at a local stand-in. Defaults to https://slack.com/api/.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SLACK_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries for Slack API calls that are rate limited or fail with a transient server error. Defaults to 5.",
+			},
+			"retry_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SLACK_RETRY_TIMEOUT", int(defaultRetryTimeout.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum total time in seconds spent on a single Slack API call, including retries. Defaults to 300.",
+			},
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SLACK_HTTP_PROXY", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  "URL of the proxy used for Slack API calls. If unset, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables apply.",
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("SLACK_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a PEM-encoded CA bundle trusted in addition to the system roots, e.g. for a TLS-intercepting proxy.",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM-encoded CA bundle trusted in addition to the system roots, e.g. for a TLS-intercepting proxy.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SLACK_REQUEST_TIMEOUT", int(defaultRequestTimeout.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Timeout in seconds for a single HTTP request to the Slack API. Defaults to 30.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip TLS certificate verification for Slack API calls. Only use this for debugging.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"slack_channel":   resourceSlackChannel(),
-			"slack_usergroup": resourceSlackUsergroup(),
+			"slack_channel":          resourceSlackChannel(),
+			"slack_channel_member":   resourceSlackChannelMember(),
+			"slack_usergroup":        resourceSlackUsergroup(),
+			"slack_usergroup_member": resourceSlackUsergroupMember(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"slack_user":        dataSourceSlackUser(),
 			"slack_users":       dataSourceSlackUsers(),
 			"slack_users_group": dataSourceSlackUsersGroup(),
+			"slack_usergroup":   dataSourceSlackUsergroup(),
+			"slack_usergroups":  dataSourceSlackUsergroups(),
 			"slack_channel":     dataSourceSlackChannel(),
 			"slack_channels":    dataSourceSlackChannels(),
 		},
-		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-			token := d.Get("token").(string)
-			if token == "" {
-				return nil, diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "Slack token is missing",
-						Detail:   "Please provide a Slack token using the 'token' argument or the SLACK_TOKEN environment variable.",
-					},
-				}
-			}
-
-			client := slack.New(token)
-
-			//Validate token authenticity
-			_, err := client.AuthTest()
-			if err != nil {
-				return nil, diag.Diagnostics{
-					diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "Invalid Slack token",
-						Detail:   "Authentication with Slack API failed. Please check if your token is valid and has the correct permissions.",
-					},
-				}
-			}
-
-			return client, nil
-		},
 	}
+	p.ConfigureContextFunc = providerConfigure(p)
+	return p
+}
+
+// providerMeta is returned by providerConfigure and passed as meta to every
+// resource and data source. client uses the bot token; userClient,
+// adminClient and admin are nil unless the matching token is configured.
+type providerMeta struct {
+	client      slackClient
+	userClient  slackClient
+	adminClient slackClient
+	admin       slackAdminClient
+	cache       *workspaceCache
+
+	// usergroupLocks serializes read-modify-write updates of usergroup
+	// members.
+	usergroupLocks *keyedMutex
+
+	// userTokenUserID is the user behind user_token, if any.
+	userTokenUserID string
+
+	// scopes, userScopes and adminScopes are the OAuth scopes granted to
+	// each token, or nil if the token is not configured or Slack did not
+	// report them.
+	scopes      scopeSet
+	userScopes  scopeSet
+	adminScopes scopeSet
+}
+
+func newProviderMeta(client slackClient) *providerMeta {
+	return &providerMeta{
+		client:         client,
+		cache:          newWorkspaceCache(client),
+		usergroupLocks: newKeyedMutex(),
+	}
+}
+
+func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configureSlackClient(ctx, d, p.UserAgent("terraform-provider-slack", Version))
+	}
+}
+
+func configureSlackClient(ctx context.Context, d *schema.ResourceData, userAgent string) (interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	token := d.Get("bot_token").(string)
+	if token == "" {
+		token = d.Get("token").(string)
+	}
+	if token == "" {
+		return nil, diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Slack token is missing",
+				Detail:   "Please provide a Slack bot token using the 'bot_token' argument or the SLACK_BOT_TOKEN environment variable.",
+			},
+		}
+	}
+
+	// slack-go appends method names directly to the base URL
+	apiURL := d.Get("api_url").(string)
+	if !strings.HasSuffix(apiURL, "/") {
+		apiURL += "/"
+	}
+
+	httpClient, err := newHTTPClient(httpConfig{
+		proxyURL:           d.Get("http_proxy").(string),
+		caCertFile:         d.Get("ca_cert_file").(string),
+		caCertPEM:          d.Get("ca_cert_pem").(string),
+		insecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		timeout:            time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		userAgent:          userAgent,
+	})
+	if err != nil {
+		return nil, diag.Errorf("error configuring the Slack HTTP client: %s", err)
+	}
+	if d.Get("insecure_skip_verify").(bool) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TLS certificate verification is disabled",
+			Detail:   "insecure_skip_verify is set, so the provider does not verify the Slack API's TLS certificate. Use ca_cert_file or ca_cert_pem instead where possible.",
+		})
+	}
+
+	maxRetries := d.Get("max_retries").(int)
+	retryTimeout := time.Duration(d.Get("retry_timeout").(int)) * time.Second
+	// Each client records its token's scopes from the auth.test response
+	newClient := func(token string) (slackClient, *scopeRecorder) {
+		scopes := &scopeRecorder{base: httpClient.Transport}
+		tokenHTTPClient := *httpClient
+		tokenHTTPClient.Transport = scopes
+		return newRetryClient(slack.New(token, slack.OptionAPIURL(apiURL), slack.OptionHTTPClient(&tokenHTTPClient)), maxRetries, retryTimeout), scopes
+	}
+	client, scopes := newClient(token)
+
+	//Validate token authenticity
+	auth, err := client.AuthTestContext(ctx)
+	if err != nil {
+		return nil, diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid Slack token",
+				Detail:   fmt.Sprintf("Authentication with Slack API failed: %s. Please check if your token is valid and has the correct permissions.", err),
+			},
+		}
+	}
+
+	meta := newProviderMeta(client)
+	meta.cache.setBot(auth)
+	meta.scopes = scopes.granted()
+	if meta.scopes == nil {
+		tflog.Warn(ctx, "Slack did not report the token's OAuth scopes; skipping plan-time scope checks")
+	}
+
+	if userToken := d.Get("user_token").(string); userToken != "" {
+		var userScopes *scopeRecorder
+		meta.userClient, userScopes = newClient(userToken)
+		userAuth, err := meta.userClient.AuthTestContext(ctx)
+		if err != nil {
+			return nil, diag.Errorf("Invalid Slack user token: authentication with Slack API failed: %s", err)
+		}
+		meta.userTokenUserID = userAuth.UserID
+		meta.userScopes = userScopes.granted()
+	}
+
+	if adminToken := d.Get("admin_token").(string); adminToken != "" {
+		var adminScopes *scopeRecorder
+		meta.adminClient, adminScopes = newClient(adminToken)
+		if _, err := meta.adminClient.AuthTestContext(ctx); err != nil {
+			return nil, diag.Errorf("Invalid Slack admin token: authentication with Slack API failed: %s", err)
+		}
+		meta.adminScopes = adminScopes.granted()
+		meta.admin = newRetryAdminClient(newAdminAPIClient(httpClient, apiURL, adminToken), maxRetries, retryTimeout)
+	}
+
+	return meta, diags
 }
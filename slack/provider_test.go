@@ -1,6 +1,12 @@
 package slack
 
-import "testing"
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func TestProvider(t *testing.T) {
 	p := Provider()
@@ -13,4 +19,66 @@ func TestProvider(t *testing.T) {
 	if _, ok := p.Schema["token"]; !ok {
 		t.Errorf("token attribute missing in provider schema")
 	}
-}
\ No newline at end of file
+}
+
+func TestProviderConfigure_apiURL(t *testing.T) {
+	fake := newFakeSlack(t)
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"token":   "xoxb-fake",
+		"api_url": fake.server.URL,
+	})
+	meta, diags := providerConfigure(Provider())(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected configure error: %v", diags)
+	}
+	if calls := fake.callCount("auth.test"); calls != 1 {
+		t.Errorf("expected auth.test to reach the fake server once, got %d calls", calls)
+	}
+
+	bot, err := meta.(*providerMeta).cache.Bot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if bot.UserID != fakeBotUserID {
+		t.Errorf("expected bot user %s, got %s", fakeBotUserID, bot.UserID)
+	}
+}
+
+func TestProviderConfigure_tokens(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("UADMIN0001", "admin", "")
+	fake.addToken("xoxp-user", "UADMIN0001")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"bot_token":  "xoxb-fake",
+		"user_token": "xoxp-user",
+		"api_url":    fake.server.URL,
+	})
+	meta, diags := providerConfigure(Provider())(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected configure error: %v", diags)
+	}
+	m := meta.(*providerMeta)
+
+	if m.userTokenUserID != "UADMIN0001" {
+		t.Errorf("expected user token user UADMIN0001, got %q", m.userTokenUserID)
+	}
+	if api, err := m.clientFor(tokenUser, "conversations.kick"); err != nil || api != m.userClient {
+		t.Errorf("expected the user client for user calls, got %v (err %v)", api, err)
+	}
+	if _, err := m.clientFor(tokenAdmin, "admin.conversations.delete"); err == nil || !strings.Contains(err.Error(), "admin_token") {
+		t.Errorf("expected an error naming admin_token, got %v", err)
+	}
+}
+
+func TestProviderConfigure_missingToken(t *testing.T) {
+	t.Setenv("SLACK_TOKEN", "")
+	t.Setenv("SLACK_BOT_TOKEN", "")
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	_, diags := providerConfigure(Provider())(context.Background(), d)
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "bot_token") {
+		t.Errorf("expected an error naming bot_token, got %v", diags)
+	}
+}
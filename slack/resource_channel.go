@@ -1,7 +1,26 @@
 package slack
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// on_conflict values for slack_channel.
+const (
+	channelConflictAdopt          = "adopt"
+	channelConflictAdoptUnarchive = "adopt_and_unarchive"
+	channelConflictFail           = "fail"
+)
+
+// deletion_policy values for slack_channel.
+const (
+	channelDeletionArchive = "archive"
+	channelDeletionDelete  = "delete"
+	channelDeletionAbandon = "abandon"
 )
 
 func resourceSlackChannel() *schema.Resource {
@@ -10,22 +29,40 @@ func resourceSlackChannel() *schema.Resource {
 		ReadContext:   resourceSlackChannelRead,
 		UpdateContext: resourceSlackChannelUpdate,
 		DeleteContext: resourceSlackChannelDelete,
+		// Sequence rather than All, so that errors carrying an attribute
+		// path reach Terraform unwrapped.
+		CustomizeDiff: customdiff.Sequence(
+			requireScopes("slack_channel"),
+			validateMemberEmailsDiff,
+			resourceSlackChannelVisibilityDiff,
+			resourceSlackChannelRenameDiff,
+			requireToken(tokenAdmin, "deletion_policy = \"delete\"", func(d *schema.ResourceDiff) bool {
+				return d.Get("deletion_policy").(string) == channelDeletionDelete
+			}),
+		),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSlackChannelImport,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the channel (without #).",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlackName("channel name", false),
+				Description:      "The name of the channel (without #).",
 			},
 			"is_private": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "Whether the channel is private (true) or public (false).",
+				Description: "Whether the channel is private (true) or public (false). Changing it converts the channel in place when the provider has an admin_token; otherwise it requires recreate_on_visibility_change.",
+			},
+			"recreate_on_visibility_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true and no admin_token is configured, changing is_private replaces the channel; the change must also set a new name, because the replaced channel keeps its name. If false (default), such a change fails at plan time.",
 			},
 			"members": {
 				Type:        schema.TypeSet,
@@ -34,11 +71,48 @@ func resourceSlackChannel() *schema.Resource {
 				Set:         schema.HashString,
 				Description: "List of user IDs to add to the channel.",
 			},
+			"member_emails": memberEmailsSchema("Email addresses of users to add to the channel. They are resolved to user IDs at plan time, and an address without an active Slack user is an error. Requires the users:read.email scope."),
+			"member_usergroup_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of usergroups whose members are added to the channel. Membership is expanded on every apply, so users added to a usergroup join the channel on the next apply. Deactivated users and guests are skipped. Requires the usergroups:read and users:read scopes.",
+			},
 			"strict_members": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "If true, Terraform will detect drift when users are manually added to the channel. If false (default), Terraform only manages the declared members and ignores manually added users.",
+				Description: "If true, Terraform will detect drift when users are manually added to the channel and remove members that are not declared. If false (default), Terraform only manages the declared members and ignores manually added users.",
+			},
+			"exempt_members": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "List of user IDs that are never removed from the channel when strict_members is true (e.g. workspace owners). The bot user is always exempt.",
+			},
+			"on_conflict": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  channelConflictAdopt,
+				ValidateFunc: validation.StringInSlice([]string{
+					channelConflictAdopt,
+					channelConflictAdoptUnarchive,
+					channelConflictFail,
+				}, false),
+				Description: "What to do on create when a channel with the same name already exists: 'adopt' (default) manages the existing channel, 'adopt_and_unarchive' also unarchives it if needed, and 'fail' returns an error. 'adopt' takes over an archived channel without unarchiving it.",
+			},
+			"deletion_policy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  channelDeletionArchive,
+				ValidateFunc: validation.StringInSlice([]string{
+					channelDeletionArchive,
+					channelDeletionDelete,
+					channelDeletionAbandon,
+				}, false),
+				Description: "What happens to the channel when the resource is destroyed: 'archive' (default) archives it, 'delete' permanently deletes it with admin.conversations.delete (requires admin_token), and 'abandon' only removes it from the Terraform state.",
 			},
 			"purpose": {
 				Type:        schema.TypeString,
@@ -53,3 +127,47 @@ func resourceSlackChannel() *schema.Resource {
 		},
 	}
 }
+
+// resourceSlackChannelVisibilityDiff decides how a change of is_private is
+// applied: converted in place with the admin token, replaced when the
+// configuration opts in and renames the channel, or rejected at plan time.
+// A replacement must pick a new name because the destroyed channel is
+// archived and keeps its name, which the new channel would then collide
+// with on create.
+func resourceSlackChannelVisibilityDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("is_private") {
+		return nil
+	}
+	if m, ok := meta.(*providerMeta); ok && m.hasToken(tokenAdmin) {
+		return nil
+	}
+	if d.Get("recreate_on_visibility_change").(bool) {
+		if !d.HasChange("name") {
+			return fmt.Errorf("replacing channel %s to change is_private requires a new name: the replaced channel is archived and keeps the name '%s'. Change 'name' together with 'is_private', or set 'admin_token' to convert the channel in place", d.Id(), d.Get("name").(string))
+		}
+		return d.ForceNew("is_private")
+	}
+	return fmt.Errorf("changing is_private of an existing channel requires a Slack admin user token for admin.conversations.convertToPrivate/convertToPublic: set 'admin_token' in the provider configuration, or set recreate_on_visibility_change = true to replace the channel")
+}
+
+// resourceSlackChannelRenameDiff fails the plan when a channel is renamed to
+// a name already used by another channel, including archived ones.
+func resourceSlackChannelRenameDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("name") || !d.NewValueKnown("name") {
+		return nil
+	}
+	m, ok := meta.(*providerMeta)
+	if !ok {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	existing, _, err := findChannelByName(ctx, m.cache, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != d.Id() {
+		return fmt.Errorf("cannot rename channel %s to '%s': the name is already used by channel %s (archived: %v)", d.Id(), name, existing.ID, existing.IsArchived)
+	}
+	return nil
+}
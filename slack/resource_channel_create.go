@@ -12,49 +12,37 @@ import (
 
 func resourceSlackChannelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
+	api := m.client
 
 	name := d.Get("name").(string)
 	isPrivate := d.Get("is_private").(bool)
 
-	// Parse members - convert from schema.Set to []string
-	var members []string
-	if membersRaw, ok := d.GetOk("members"); ok {
-		members = convertSchemaSetToStringSlice(membersRaw.(*schema.Set))
+	// Collect members declared by ID, by email and through usergroups
+	members, err := desiredChannelMembers(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
+	strictMembers := d.Get("strict_members").(bool)
+	exemptMembers := convertSchemaSetToStringSlice(d.Get("exempt_members").(*schema.Set))
+
 	// Validate members for private channels
 	if isPrivate && len(members) == 0 {
 		return diag.Errorf("private channels must have at least one member listed")
 	}
 
 	// Check if channel already exists (including archived)
-	existingChannel, debugLogs, err := findChannelByName(ctx, api, name)
+	existingChannel, debugLogs, err := findChannelByName(ctx, m.cache, name)
 	for _, log := range debugLogs {
 		tflog.Debug(ctx, log)
 	}
 	if err != nil {
-		return diag.Errorf("error checking for existing channel: %s", err)
+		return slackDiagErrorf(err, "conversations.list", "error checking for existing channel")
 	}
 
 	if existingChannel != nil {
-		diagMsg := fmt.Sprintf("Channel '%s' already exists", name)
-		detail := fmt.Sprintf("Terraform will reuse this channel. Archived status: %v. Please unarchive it in Slack if necessary.", existingChannel.IsArchived)
-
-		tflog.Warn(ctx, fmt.Sprintf("%s — %s", diagMsg, detail))
-		d.SetId(existingChannel.ID)
-
-		// Sync members (automatically checks current vs desired)
-		memberDiags := syncChannelMembers(api, existingChannel.ID, members)
-		diags = append(diags, memberDiags...)
-
-		// Return warning to user
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  diagMsg,
-			Detail:   detail,
-		})
-		return diags
+		return adoptSlackChannel(ctx, d, m, existingChannel, members, strictMembers, exemptMembers)
 	}
 
 	// Create channel
@@ -62,32 +50,107 @@ func resourceSlackChannelCreate(ctx context.Context, d *schema.ResourceData, met
 		ChannelName: name,
 		IsPrivate:   isPrivate,
 	}
-	channel, err := api.CreateConversation(params)
+	channel, err := api.CreateConversationContext(ctx, params)
 	if err != nil {
-		return diag.Errorf("error creating channel: %s", err)
+		return slackDiagErrorf(err, "conversations.create", "error creating channel")
 	}
 	d.SetId(channel.ID)
+	m.cache.InvalidateChannels()
 
-	// Set purpose if provided
-	if v, ok := d.GetOk("purpose"); ok && v.(string) != "" {
-		_, err := api.SetPurposeOfConversation(channel.ID, v.(string))
-		if err != nil {
-			return diag.Errorf("error setting channel purpose: %s", err)
-		}
+	// Set purpose and topic if provided
+	if diags := setChannelPurposeAndTopic(ctx, api, d, channel); diags.HasError() {
+		return diags
 	}
 
-	// Set topic if provided
-	if v, ok := d.GetOk("topic"); ok && v.(string) != "" {
-		_, err := api.SetTopicOfConversation(channel.ID, v.(string))
-		if err != nil {
-			return diag.Errorf("error setting channel topic: %s", err)
-		}
-	}
-	
 	// Sync members
-	memberDiags := syncChannelMembers(api, channel.ID, members)
+	memberDiags := syncChannelMembers(ctx, m, channel.ID, members, strictMembers, exemptMembers)
 	diags = append(diags, memberDiags...)
 
 	tflog.Info(ctx, fmt.Sprintf("Slack channel '%s' created successfully", name))
 	return diags
 }
+
+// adoptSlackChannel brings an existing channel with the configured name under
+// management according to on_conflict, then applies the declared purpose,
+// topic and members to it.
+func adoptSlackChannel(ctx context.Context, d *schema.ResourceData, m *providerMeta, channel *slack.Channel, members []string, strictMembers bool, exemptMembers []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	api := m.client
+	name := d.Get("name").(string)
+	onConflict := d.Get("on_conflict").(string)
+
+	if onConflict == channelConflictFail {
+		return diag.Errorf("channel '%s' already exists (ID: %s, archived: %v); import it or set on_conflict to adopt it", name, channel.ID, channel.IsArchived)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Adopting existing Slack channel '%s' (%s)", name, channel.ID))
+
+	if isPrivate := d.Get("is_private").(bool); isPrivate != channel.IsPrivate {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Channel '%s' has a different visibility", name),
+			Detail: fmt.Sprintf("The adopted channel %s has is_private = %v, but the configuration sets is_private = %v. The channel was adopted as it is. "+
+				"The next plan converts it with the admin_token, or fails unless recreate_on_visibility_change is set.", channel.ID, channel.IsPrivate, isPrivate),
+		})
+	}
+
+	// Slack does not allow changes to an archived channel, so one adopted
+	// without unarchiving is taken over as it is
+	if channel.IsArchived && onConflict != channelConflictAdoptUnarchive {
+		d.SetId(channel.ID)
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Channel '%s' already exists and is archived", name),
+			Detail: fmt.Sprintf("Terraform adopted the archived channel %s without changing it: Slack does not allow changes to archived channels, so the declared purpose, topic and members were not applied. "+
+				"Unarchive it in Slack, or set on_conflict = \"%s\" to have Terraform unarchive it.", channel.ID, channelConflictAdoptUnarchive),
+		})
+	}
+
+	if channel.IsArchived {
+		if err := api.UnArchiveConversationContext(ctx, channel.ID); err != nil {
+			return slackDiagErrorf(err, "conversations.unarchive", "error unarchiving channel '%s'", name)
+		}
+		m.cache.InvalidateChannels()
+	}
+
+	// The bot must be a member to change the channel. It can only join
+	// public channels; for private ones it must already have been invited.
+	if !channel.IsPrivate {
+		if _, _, _, err := api.JoinConversationContext(ctx, channel.ID); err != nil {
+			return slackDiagErrorf(err, "conversations.join", "error joining channel '%s'", name)
+		}
+	}
+
+	d.SetId(channel.ID)
+
+	if diags := setChannelPurposeAndTopic(ctx, api, d, channel); diags.HasError() {
+		return diags
+	}
+
+	diags = append(diags, syncChannelMembers(ctx, m, channel.ID, members, strictMembers, exemptMembers)...)
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Channel '%s' already exists", name),
+		Detail:   fmt.Sprintf("Terraform adopted the existing channel %s instead of creating a new one (on_conflict = \"%s\").", channel.ID, onConflict),
+	})
+	return diags
+}
+
+// setChannelPurposeAndTopic applies the declared purpose and topic where
+// they differ from the channel's current values.
+func setChannelPurposeAndTopic(ctx context.Context, api slackClient, d *schema.ResourceData, channel *slack.Channel) diag.Diagnostics {
+	if v, ok := d.GetOk("purpose"); ok && v.(string) != channel.Purpose.Value {
+		if _, err := api.SetPurposeOfConversationContext(ctx, channel.ID, v.(string)); err != nil {
+			return slackDiagErrorf(err, "conversations.setPurpose", "error setting channel purpose")
+		}
+	}
+
+	if v, ok := d.GetOk("topic"); ok && v.(string) != channel.Topic.Value {
+		if _, err := api.SetTopicOfConversationContext(ctx, channel.ID, v.(string)); err != nil {
+			return slackDiagErrorf(err, "conversations.setTopic", "error setting channel topic")
+		}
+	}
+
+	return nil
+}
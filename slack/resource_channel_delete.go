@@ -4,49 +4,104 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/slack-go/slack"
 )
 
 func resourceSlackChannelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
 	channelID := d.Id()
 
+	switch d.Get("deletion_policy").(string) {
+	case channelDeletionAbandon:
+		tflog.Info(ctx, fmt.Sprintf("Leaving Slack channel %s in place (deletion_policy = abandon)", channelID))
+		return nil
+	case channelDeletionDelete:
+		return deleteSlackChannel(ctx, m, channelID)
+	}
+	return archiveSlackChannel(ctx, m, channelID)
+}
+
+// deleteSlackChannel permanently deletes the channel with
+// admin.conversations.delete.
+func deleteSlackChannel(ctx context.Context, m *providerMeta, channelID string) diag.Diagnostics {
+	admin, err := m.adminAPI("deletion_policy = \"delete\"")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := admin.DeleteConversationContext(ctx, channelID); err != nil {
+		if isSlackNotFound(err) {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Slack channel not found",
+					Detail:   fmt.Sprintf("Terraform could not find the channel '%s'. It may have been deleted manually.", channelID),
+				},
+			}
+		}
+		return slackDiagErrorf(err, "admin.conversations.delete", "error deleting Slack channel '%s'", channelID)
+	}
+
+	m.cache.InvalidateChannels()
+	return nil
+}
+
+// archiveSlackChannel joins and archives the channel.
+func archiveSlackChannel(ctx context.Context, m *providerMeta, channelID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	api := m.client
+
 	// Attempt to join the channel (required before archiving)
-	_, _, _, err := api.JoinConversation(channelID)
+	_, _, _, err := api.JoinConversationContext(ctx, channelID)
 	if err != nil {
+		if isSlackNotFound(err) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Slack channel not found",
+				Detail:   fmt.Sprintf("Terraform could not find the channel '%s'. It may have been deleted manually.", channelID),
+			})
+			return diags
+		}
+		if slackErrorCode(err) == "is_archived" {
+			// Nothing left to do
+			return diags
+		}
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Unable to join Slack channel",
-			Detail:   fmt.Sprintf("Bot could not join channel '%s': %v. Terraform will attempt to archive it anyway.", channelID, err),
+			Detail:   fmt.Sprintf("Bot could not join channel '%s': %v. Terraform will attempt to archive it anyway. %s", channelID, err, slackErrorDetail(err, "conversations.join")),
 		})
 	}
 
 	// Attempt to archive the channel
-	err = api.ArchiveConversation(channelID)
+	err = api.ArchiveConversationContext(ctx, channelID)
 	if err != nil {
-		switch err.Error() {
-		case "not_in_channel":
+		switch {
+		case slackErrorCode(err) == "not_in_channel":
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
 				Summary:  "Bot is not a member of the Slack channel",
 				Detail:   fmt.Sprintf("Terraform could not archive the channel '%s' because the bot is not a member. Please archive it manually in Slack.", channelID),
 			})
 			return diags
-		case "channel_not_found":
+		case isSlackNotFound(err):
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
 				Summary:  "Slack channel not found",
 				Detail:   fmt.Sprintf("Terraform could not find the channel '%s'. It may have been deleted manually.", channelID),
 			})
 			return diags
+		case slackErrorCode(err) == "already_archived":
+			// Someone archived it in Slack already; nothing left to do
 		default:
-			return diag.FromErr(fmt.Errorf("error archiving Slack channel '%s': %w", channelID, err))
+			return slackDiagErrorf(err, "conversations.archive", "error archiving Slack channel '%s'", channelID)
 		}
 	}
 
+	m.cache.InvalidateChannels()
+
 	// No need to log success — Terraform CLI handles positive feedback itself
 	return diags
 }
@@ -9,33 +9,47 @@ import (
 
 // findChannelByName searches for a Slack channel by its name.
 // Returns the channel object if found (including archived), or nil if not found.
+// The channel list comes from the workspace cache, so repeated lookups in one
+// run only page through conversations.list once.
 // Logs and diagnostics are returned as formatted strings for flexibility.
-func findChannelByName(ctx context.Context, api *slack.Client, name string) (*slack.Channel, []string, error) {
+func findChannelByName(ctx context.Context, cache *workspaceCache, name string) (*slack.Channel, []string, error) {
 	var debugLogs []string
-	cursor := ""
 
-	for {
-		debugLogs = append(debugLogs, fmt.Sprintf("[DEBUG] Querying Slack API for channels (cursor: '%s')", cursor))
+	channels, err := cache.Channels(ctx)
+	if err != nil {
+		return nil, debugLogs, fmt.Errorf("failed to list Slack channels: %w", err)
+	}
 
-		channels, nextCursor, err := api.GetConversations(&slack.GetConversationsParameters{
-			ExcludeArchived: false,
-			Limit:           1000,
-			Cursor:          cursor,
-			Types:           []string{"public_channel", "private_channel"},
-		})
-		if err != nil {
-			return nil, debugLogs, fmt.Errorf("failed to list Slack channels: %w", err)
+	debugLogs = append(debugLogs, fmt.Sprintf("[DEBUG] Searching %d cached channels", len(channels)))
+
+	for i := range channels {
+		c := channels[i]
+		if c.Name == name {
+			debugLogs = append(debugLogs, fmt.Sprintf("[INFO] Matched requested channel '%s' (ID: %s, Archived: %v)", name, c.ID, c.IsArchived))
+			return &c, debugLogs, nil
 		}
+	}
 
-		debugLogs = append(debugLogs, fmt.Sprintf("[DEBUG] Slack API returned %d channels", len(channels)))
+	debugLogs = append(debugLogs, fmt.Sprintf("[INFO] Channel '%s' not found in Slack API (even including archived)", name))
+	return nil, debugLogs, nil
+}
+
+// getChannelMembers returns every member of a Slack channel, following the
+// conversations.members cursor until all pages have been read.
+func getChannelMembers(ctx context.Context, api slackClient, channelID string) ([]string, error) {
+	var members []string
+	cursor := ""
 
-		for _, c := range channels {
-			debugLogs = append(debugLogs, fmt.Sprintf("[DEBUG] Found channel: %s (ID: %s, Archived: %v)", c.Name, c.ID, c.IsArchived))
-			if c.Name == name {
-				debugLogs = append(debugLogs, fmt.Sprintf("[INFO] Matched requested channel '%s' (Archived: %v)", name, c.IsArchived))
-				return &c, debugLogs, nil
-			}
+	for {
+		page, nextCursor, err := api.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+			ChannelID: channelID,
+			Limit:     1000,
+			Cursor:    cursor,
+		})
+		if err != nil {
+			return nil, err
 		}
+		members = append(members, page...)
 
 		if nextCursor == "" {
 			break
@@ -43,6 +57,5 @@ func findChannelByName(ctx context.Context, api *slack.Client, name string) (*sl
 		cursor = nextCursor
 	}
 
-	debugLogs = append(debugLogs, fmt.Sprintf("[INFO] Channel '%s' not found in Slack API (even including archived)", name))
-	return nil, debugLogs, nil
+	return members, nil
 }
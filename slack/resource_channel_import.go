@@ -0,0 +1,53 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceSlackChannelImport imports a channel by ID (C0123456789) or by
+// name (name:<channel-name>). The current members are imported as the
+// declared members, and the other arguments get their default values, so a
+// matching configuration plans no changes.
+func resourceSlackChannelImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	m := meta.(*providerMeta)
+
+	if name, ok := strings.CutPrefix(d.Id(), "name:"); ok {
+		channel, debugLogs, err := findChannelByName(ctx, m.cache, name)
+		for _, log := range debugLogs {
+			tflog.Debug(ctx, log)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if channel == nil {
+			return nil, fmt.Errorf("no Slack channel named '%s' found", name)
+		}
+		d.SetId(channel.ID)
+	}
+
+	members, err := getChannelMembers(ctx, m.client, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error reading members of Slack channel '%s': %w", d.Id(), err)
+	}
+	declared := removeMember(members, m.botUserID(ctx))
+
+	values := map[string]interface{}{
+		"members":                       declared,
+		"strict_members":                false,
+		"recreate_on_visibility_change": false,
+		"on_conflict":                   channelConflictAdopt,
+		"deletion_policy":               channelDeletionArchive,
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return nil, fmt.Errorf("error setting '%s': %w", key, err)
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceSlackChannelMember manages a single user's membership of a
+// channel. Unlike the members argument of slack_channel it only invites or
+// kicks its own user and never looks at the rest of the channel.
+func resourceSlackChannelMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSlackChannelMemberCreate,
+		ReadContext:   resourceSlackChannelMemberRead,
+		DeleteContext: resourceSlackChannelMemberDelete,
+		CustomizeDiff: requireScopes("slack_channel_member"),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"channel_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the channel.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the user to add to the channel.",
+			},
+		},
+	}
+}
+
+// parseChannelMemberID splits a "C123:U456" resource ID.
+func parseChannelMemberID(id string) (channelID, userID string, err error) {
+	return parseMemberID("slack_channel_member", "channel_id", id)
+}
+
+func resourceSlackChannelMemberCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	api := m.client
+	channelID := d.Get("channel_id").(string)
+	userID := d.Get("user_id").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Adding user %s to Slack channel %s", userID, channelID))
+
+	_, err := api.InviteUsersToConversationContext(ctx, channelID, userID)
+	m.cache.InvalidateChannelMembers(channelID)
+	if err != nil && slackErrorCode(err) != "already_in_channel" {
+		return slackDiagErrorf(err, "conversations.invite", "error adding user %s to Slack channel %s", userID, channelID)
+	}
+
+	d.SetId(memberID(channelID, userID))
+	return resourceSlackChannelMemberRead(ctx, d, meta)
+}
+
+func resourceSlackChannelMemberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+
+	channelID, userID, err := parseChannelMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Memberships of one channel share a single conversations.members listing
+	members, err := m.cache.ChannelMembers(ctx, channelID)
+	if err != nil {
+		if isSlackNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Slack channel %s not found, removing membership %s from state", channelID, d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return slackDiagErrorf(err, "conversations.members", "error reading members of Slack channel %s", channelID)
+	}
+
+	if !hasMember(members, userID) {
+		tflog.Warn(ctx, fmt.Sprintf("User %s is no longer in Slack channel %s, removing from state", userID, channelID))
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("channel_id", channelID); err != nil {
+		return diag.Errorf("error setting 'channel_id': %s", err)
+	}
+	if err := d.Set("user_id", userID); err != nil {
+		return diag.Errorf("error setting 'user_id': %s", err)
+	}
+	return nil
+}
+
+func resourceSlackChannelMemberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	channelID, userID, err := parseChannelMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	api, err := m.clientFor(tokenUser, "conversations.kick")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Removing user %s from Slack channel %s", userID, channelID))
+
+	err = kickChannelMember(ctx, m, api, channelID, userID)
+	m.cache.InvalidateChannelMembers(channelID)
+	if err != nil {
+		if isSlackNotFound(err) || slackErrorCode(err) == "not_in_channel" {
+			// The user left or the channel is gone, so nothing is left to kick
+			return nil
+		}
+		return slackDiagErrorf(err, "conversations.kick", "error removing user %s from Slack channel %s", userID, channelID)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package slack
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceSlackChannelMemberLifecycle(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	channel := fake.addChannel("central", false, fakeBotUserID, "U001")
+	ctx := context.Background()
+	meta := fake.meta()
+
+	d := schema.TestResourceDataRaw(t, resourceSlackChannelMember().Schema, map[string]interface{}{
+		"channel_id": channel,
+		"user_id":    "U002",
+	})
+	if diags := resourceSlackChannelMemberCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	if want := channel + ":U002"; d.Id() != want {
+		t.Errorf("expected ID %s, got %s", want, d.Id())
+	}
+	want := []string{"U001", "U002", fakeBotUserID}
+	if got := fake.channelMembers(channel); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+
+	// Creating a membership that already exists is not an error
+	existing := schema.TestResourceDataRaw(t, resourceSlackChannelMember().Schema, map[string]interface{}{
+		"channel_id": channel,
+		"user_id":    "U001",
+	})
+	if diags := resourceSlackChannelMemberCreate(ctx, existing, meta); diags.HasError() {
+		t.Fatalf("unexpected error for an existing member: %v", diags)
+	}
+
+	// Import by ID
+	imported := resourceSlackChannelMember().Data(nil)
+	imported.SetId(channel + ":U002")
+	if diags := resourceSlackChannelMemberRead(ctx, imported, meta); diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	if imported.Get("channel_id").(string) != channel || imported.Get("user_id").(string) != "U002" {
+		t.Errorf("expected channel_id and user_id to be read from the ID, got %q and %q", imported.Get("channel_id"), imported.Get("user_id"))
+	}
+
+	if diags := resourceSlackChannelMemberDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected delete error: %v", diags)
+	}
+	want = []string{"U001", fakeBotUserID}
+	if got := fake.channelMembers(channel); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+
+	// A removed membership disappears from state, and deleting it again is a no-op
+	if diags := resourceSlackChannelMemberRead(ctx, d, meta); diags.HasError() || d.Id() != "" {
+		t.Errorf("expected the removed membership to be dropped from state, got ID %q (%v)", d.Id(), diags)
+	}
+	d.SetId(channel + ":U002")
+	if diags := resourceSlackChannelMemberDelete(ctx, d, meta); diags.HasError() {
+		t.Errorf("unexpected error deleting a removed membership: %v", diags)
+	}
+}
+
+func TestParseChannelMemberID(t *testing.T) {
+	for _, id := range []string{"C123", "C123:", ":U456", "C123:U456:X"} {
+		if _, _, err := parseChannelMemberID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+	if c, u, err := parseChannelMemberID("C123:U456"); err != nil || c != "C123" || u != "U456" {
+		t.Errorf("expected C123 and U456, got %q, %q, %v", c, u, err)
+	}
+}
+
+func TestResourceSlackChannelMemberRead_listsMembersOnce(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	channel := fake.addChannel("central", false, fakeBotUserID, "U001", "U002")
+	ctx := context.Background()
+	meta := fake.meta()
+
+	for _, user := range []string{"U001", "U002"} {
+		d := resourceSlackChannelMember().Data(nil)
+		d.SetId(memberID(channel, user))
+		if diags := resourceSlackChannelMemberRead(ctx, d, meta); diags.HasError() || d.Id() == "" {
+			t.Fatalf("unexpected read result for %s: ID %q (%v)", user, d.Id(), diags)
+		}
+	}
+	if got := fake.callCount("conversations.members"); got != 1 {
+		t.Errorf("expected the channel's members to be listed once per run, got %d calls", got)
+	}
+}
@@ -14,7 +14,8 @@ import (
 // and updates the Terraform state. It gracefully handles deleted channels and
 // filters out the bot user from the members list to prevent unwanted diffs.
 func resourceSlackChannelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
+	api := m.client
 	var diags diag.Diagnostics
 
 	channelID := d.Id()
@@ -23,12 +24,12 @@ func resourceSlackChannelRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// Fetch basic channel info
-	info, err := api.GetConversationInfo(&slack.GetConversationInfoInput{
+	info, err := api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 		ChannelID: channelID,
 	})
 	if err != nil {
 		// Handle channel manually deleted in Slack
-		if err.Error() == "channel_not_found" {
+		if isSlackNotFound(err) {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
 				Summary:  fmt.Sprintf("Slack channel '%s' no longer exists", channelID),
@@ -37,7 +38,7 @@ func resourceSlackChannelRead(ctx context.Context, d *schema.ResourceData, meta
 			d.SetId("") // Remove from Terraform state
 			return diags
 		}
-		return diag.Errorf("error reading channel info: %s", err)
+		return slackDiagErrorf(err, "conversations.info", "error reading channel info")
 	}
 
 	// Set core attributes
@@ -56,10 +57,7 @@ func resourceSlackChannelRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// Fetch channel members
-	members, _, err := api.GetUsersInConversation(&slack.GetUsersInConversationParameters{
-		ChannelID: channelID,
-		Limit:     1000,
-	})
+	members, err := getChannelMembers(ctx, api, channelID)
 	if err != nil {
 		tflog.Warn(ctx, fmt.Sprintf("failed to fetch members for channel '%s': %s", channelID, err))
 		// Proceed without setting "members"
@@ -67,11 +65,11 @@ func resourceSlackChannelRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	// Fetch bot's own user ID to exclude from member list
-	authResp, err := api.AuthTest()
+	bot, err := m.cache.Bot(ctx)
 	if err != nil {
 		tflog.Warn(ctx, fmt.Sprintf("failed to identify bot user ID: %s", err))
 	} else {
-		botUserID := authResp.UserID
+		botUserID := bot.UserID
 		filtered := make([]string, 0, len(members))
 		for _, m := range members {
 			if m != botUserID {
@@ -81,12 +79,39 @@ func resourceSlackChannelRead(ctx context.Context, d *schema.ResourceData, meta
 		members = filtered
 	}
 
+	// Users declared only through member_emails or member_usergroup_ids
+	// are tracked there
+	emailOnly, err := readMemberEmails(ctx, d, m.cache, members)
+	if err != nil {
+		return diag.Errorf("error reading 'member_emails': %s", err)
+	}
+	groupOnly, err := readMemberUsergroups(ctx, d, m.cache, members)
+	if err != nil {
+		return diag.Errorf("error reading 'member_usergroup_ids': %s", err)
+	}
+
 	// Check if strict_members mode is enabled
 	strictMembers := d.Get("strict_members").(bool)
 	
 	if strictMembers {
 		// STRICT MODE: Track all members in the channel (shows drift)
-		// This will cause Terraform to detect when users are manually added
+		// This will cause Terraform to detect when users are manually added.
+		// Exempt members are never removed, so undeclared ones are left out of the state.
+		exemptSet := make(map[string]bool)
+		for _, m := range convertSchemaSetToStringSlice(d.Get("exempt_members").(*schema.Set)) {
+			exemptSet[m] = true
+		}
+		for _, m := range convertSchemaSetToStringSlice(d.Get("members").(*schema.Set)) {
+			delete(exemptSet, m)
+		}
+		tracked := make([]string, 0, len(members))
+		for _, m := range members {
+			if !exemptSet[m] && !emailOnly[m] && !groupOnly[m] {
+				tracked = append(tracked, m)
+			}
+		}
+		members = tracked
+
 		if err := d.Set("members", members); err != nil {
 			return diag.Errorf("error setting 'members': %s", err)
 		}
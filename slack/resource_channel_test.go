@@ -0,0 +1,610 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestResourceSlackChannelCreate(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "alice@example.com")
+	fake.addUser("U002", "bob", "bob@example.com")
+	meta := fake.meta()
+
+	d := schema.TestResourceDataRaw(t, resourceSlackChannel().Schema, map[string]interface{}{
+		"name":    "tf-unit",
+		"purpose": "Unit testing",
+		"members": []interface{}{"U001", "U002"},
+	})
+	if diags := resourceSlackChannelCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+
+	ch := fake.channel(d.Id())
+	if ch == nil {
+		t.Fatalf("channel %s was not created", d.Id())
+	}
+	if ch.Purpose.Value != "Unit testing" {
+		t.Errorf("expected purpose to be set, got %q", ch.Purpose.Value)
+	}
+	want := []string{"U001", "U002", fakeBotUserID}
+	if got := fake.channelMembers(d.Id()); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+
+	if diags := resourceSlackChannelRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	if got := d.Get("members").(*schema.Set).Len(); got != 2 {
+		t.Errorf("expected 2 members in state (bot excluded), got %d", got)
+	}
+}
+
+func TestResourceSlackChannelCreate_onConflict(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	meta := fake.meta()
+
+	active := fake.addChannel("tf-active", false, "U001")
+	archived := fake.addChannel("tf-archived", false, "U001")
+	fake.channel(archived).IsArchived = true
+
+	create := func(cfg map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+		d := schema.TestResourceDataRaw(t, resourceSlackChannel().Schema, cfg)
+		return d, resourceSlackChannelCreate(context.Background(), d, meta)
+	}
+
+	d, diags := create(map[string]interface{}{"name": "tf-active", "topic": "Adopted", "purpose": "Adopted purpose"})
+	if diags.HasError() {
+		t.Fatalf("unexpected adopt error: %v", diags)
+	}
+	if d.Id() != active {
+		t.Errorf("expected channel %s to be adopted, got %s", active, d.Id())
+	}
+	if ch := fake.channel(active); ch.Topic.Value != "Adopted" || ch.Purpose.Value != "Adopted purpose" {
+		t.Errorf("expected adopt to apply topic and purpose, got %q and %q", ch.Topic.Value, ch.Purpose.Value)
+	}
+
+	d, diags = create(map[string]interface{}{"name": "tf-archived", "topic": "Adopted"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error adopting an archived channel: %v", diags)
+	}
+	if d.Id() != archived || !fake.channel(archived).IsArchived || fake.channel(archived).Topic.Value != "" {
+		t.Errorf("expected channel %s to be adopted unchanged, got %s", archived, d.Id())
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Summary, "is archived") {
+		t.Errorf("expected an archived channel warning, got %v", diags)
+	}
+
+	if _, diags := create(map[string]interface{}{"name": "tf-active", "on_conflict": "fail"}); !diags.HasError() {
+		t.Errorf("expected on_conflict = fail to return an error")
+	}
+
+	d, diags = create(map[string]interface{}{"name": "tf-archived", "on_conflict": "adopt_and_unarchive"})
+	if diags.HasError() {
+		t.Fatalf("unexpected adopt_and_unarchive error: %v", diags)
+	}
+	if d.Id() != archived || fake.channel(archived).IsArchived {
+		t.Errorf("expected channel %s to be unarchived and adopted, got %s", archived, d.Id())
+	}
+
+	_, diags = create(map[string]interface{}{"name": "tf-active", "is_private": true, "members": []interface{}{"U001"}})
+	if diags.HasError() {
+		t.Fatalf("unexpected error adopting a channel with another visibility: %v", diags)
+	}
+	if len(diags) == 0 || !strings.Contains(diags[0].Summary, "different visibility") {
+		t.Errorf("expected a visibility warning, got %v", diags)
+	}
+	if fake.channel(active).IsPrivate {
+		t.Errorf("expected the adopted channel to stay public")
+	}
+}
+
+func TestResourceSlackChannelDelete_policies(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("UADMIN0001", "admin", "")
+	meta := fake.adminMeta("UADMIN0001")
+
+	destroy := func(policy string) string {
+		id := fake.addChannel("tf-"+policy, false)
+		d := schema.TestResourceDataRaw(t, resourceSlackChannel().Schema, map[string]interface{}{
+			"name":            "tf-" + policy,
+			"deletion_policy": policy,
+		})
+		d.SetId(id)
+		if diags := resourceSlackChannelDelete(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected %s error: %v", policy, diags)
+		}
+		return id
+	}
+
+	if id := destroy("archive"); !fake.channel(id).IsArchived {
+		t.Errorf("expected archive to archive the channel")
+	}
+	if id := destroy("delete"); fake.channel(id) != nil {
+		t.Errorf("expected delete to remove the channel")
+	}
+	if id := destroy("abandon"); fake.channel(id).IsArchived {
+		t.Errorf("expected abandon to leave the channel untouched")
+	}
+	if calls := fake.callCount("conversations.archive"); calls != 1 {
+		t.Errorf("expected a single conversations.archive call, got %d", calls)
+	}
+
+	// Without an admin token, the delete policy fails at plan time
+	_, err := resourceSlackChannel().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":            "tf-delete",
+		"deletion_policy": "delete",
+	}), fake.meta())
+	if err == nil || !strings.Contains(err.Error(), "admin_token") {
+		t.Errorf("expected a plan error naming admin_token, got %v", err)
+	}
+}
+
+func TestResourceSlackChannelImport(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	id := fake.addChannel("tf-import", false, fakeBotUserID, "U001", "U002")
+	meta := fake.meta()
+
+	for _, importID := range []string{id, "name:tf-import"} {
+		d := resourceSlackChannel().Data(nil)
+		d.SetId(importID)
+		imported, err := resourceSlackChannelImport(context.Background(), d, meta)
+		if err != nil {
+			t.Fatalf("unexpected error importing %q: %s", importID, err)
+		}
+		d = imported[0]
+		if diags := resourceSlackChannelRead(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("unexpected read error: %v", diags)
+		}
+
+		if d.Id() != id {
+			t.Errorf("expected %q to import channel %s, got %s", importID, id, d.Id())
+		}
+		members := convertSchemaSetToStringSlice(d.Get("members").(*schema.Set))
+		sort.Strings(members)
+		if want := []string{"U001", "U002"}; !reflect.DeepEqual(members, want) {
+			t.Errorf("expected imported members %v, got %v", want, members)
+		}
+		if got := d.Get("deletion_policy").(string); got != "archive" {
+			t.Errorf("expected default deletion_policy, got %q", got)
+		}
+	}
+
+	d := resourceSlackChannel().Data(nil)
+	d.SetId("name:missing")
+	if _, err := resourceSlackChannelImport(context.Background(), d, meta); err == nil {
+		t.Errorf("expected an error importing an unknown channel name")
+	}
+}
+
+func TestResourceSlackChannelRenameDiff(t *testing.T) {
+	fake := newFakeSlack(t)
+	taken := fake.addChannel("tf-taken", false)
+	fake.channel(taken).IsArchived = true
+	meta := fake.meta()
+	r := resourceSlackChannel()
+
+	state := createTestChannel(t, meta, map[string]interface{}{"name": "tf-rename"})
+
+	_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "tf-taken",
+	}), meta)
+	if err == nil || !strings.Contains(err.Error(), taken) {
+		t.Errorf("expected a plan error naming channel %s, got %v", taken, err)
+	}
+
+	if _, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "tf-renamed",
+	}), meta); err != nil {
+		t.Errorf("unexpected plan error for a free name: %s", err)
+	}
+}
+
+func TestResourceSlackChannel_memberEmails(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "alice@example.com")
+	fake.addUser("U002", "bob", "bob@example.com")
+	meta := fake.meta()
+	r := resourceSlackChannel()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "tf-emails",
+		"members":        []interface{}{"U001"},
+		"member_emails":  []interface{}{"Bob@example.com"},
+		"strict_members": true,
+	})
+	if diags := resourceSlackChannelCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	want := []string{"U001", "U002", fakeBotUserID}
+	if got := fake.channelMembers(d.Id()); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+
+	if diags := resourceSlackChannelRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	if got := convertSchemaSetToStringSlice(d.Get("members").(*schema.Set)); !reflect.DeepEqual(got, []string{"U001"}) {
+		t.Errorf("expected users added by email to stay out of members, got %v", got)
+	}
+	if got := convertSchemaSetToStringSlice(d.Get("member_emails").(*schema.Set)); !reflect.DeepEqual(got, []string{"Bob@example.com"}) {
+		t.Errorf("expected member_emails to be kept, got %v", got)
+	}
+
+	_, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":          "tf-emails",
+		"member_emails": []interface{}{"bob@example.com", "nobody@example.com"},
+	}), meta)
+	var pathErr cty.PathError
+	if !errors.As(err, &pathErr) || !pathErr.Path.Equals(cty.GetAttrPath("member_emails")) || !strings.Contains(err.Error(), "nobody@example.com") {
+		t.Errorf("expected a member_emails error naming nobody@example.com, got %v", err)
+	}
+}
+
+func TestResourceSlackChannel_memberUsergroups(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	fake.addUser("U003", "carol", "")
+	group := fake.addUsergroup("platform", "U002")
+	meta := fake.meta()
+	r := resourceSlackChannel()
+
+	cfg := map[string]interface{}{
+		"name":                 "platform",
+		"members":              []interface{}{"U001"},
+		"member_usergroup_ids": []interface{}{group},
+		"strict_members":       true,
+	}
+	state := createTestChannel(t, meta, cfg)
+	want := []string{"U001", "U002", fakeBotUserID}
+	if got := fake.channelMembers(state.ID); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+
+	// A new usergroup member shows up as drift in the next run and is added
+	// on apply
+	fake.usergroup(group).Users = []string{"U002", "U003"}
+	meta = fake.meta()
+	d := r.Data(state)
+	if diags := resourceSlackChannelRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	if got := d.Get("member_usergroup_ids").(*schema.Set).Len(); got != 0 {
+		t.Errorf("expected the out-of-sync usergroup to be dropped from state, got %d", got)
+	}
+	if got := convertSchemaSetToStringSlice(d.Get("members").(*schema.Set)); !reflect.DeepEqual(got, []string{"U001"}) {
+		t.Errorf("expected usergroup members to stay out of members, got %v", got)
+	}
+
+	state = d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	if _, diags := r.Apply(context.Background(), state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	want = []string{"U001", "U002", "U003", fakeBotUserID}
+	if got := fake.channelMembers(state.ID); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+}
+
+func TestResourceSlackChannel_memberUsergroupsInactiveUsers(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	fake.addUser("U003", "guest", "")
+	fake.users["U002"].Deleted = true
+	fake.users["U003"].IsRestricted = true
+	group := fake.addUsergroup("platform", "U001", "U002", "U003")
+	meta := fake.meta()
+	r := resourceSlackChannel()
+
+	state := createTestChannel(t, meta, map[string]interface{}{
+		"name":                 "platform",
+		"member_usergroup_ids": []interface{}{group},
+	})
+	want := []string{"U001", fakeBotUserID}
+	if got := fake.channelMembers(state.ID); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+
+	d := r.Data(state)
+	if diags := resourceSlackChannelRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	if got := d.Get("member_usergroup_ids").(*schema.Set).Len(); got != 1 {
+		t.Errorf("expected the usergroup to stay in sync without its inactive members, got %d", got)
+	}
+	if got := fake.callCount("usergroups.users.list"); got != 1 {
+		t.Errorf("expected the usergroup's members to be listed once per run, got %d calls", got)
+	}
+}
+
+// createTestChannel creates a channel from cfg through the resource and
+// returns its state.
+func createTestChannel(t *testing.T, meta *providerMeta, cfg map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, resourceSlackChannel().Schema, cfg)
+	if diags := resourceSlackChannelCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	return d.State()
+}
+
+func TestResourceSlackChannelUpdate_convertVisibility(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("UADMIN0001", "admin", "")
+	meta := fake.adminMeta("UADMIN0001")
+	r := resourceSlackChannel()
+
+	state := createTestChannel(t, meta, map[string]interface{}{"name": "tf-convert"})
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":       "tf-convert",
+		"is_private": true,
+	}), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("expected an in-place conversion with an admin token")
+	}
+
+	if _, diags := r.Apply(context.Background(), state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	if !fake.channel(state.ID).IsPrivate {
+		t.Errorf("expected channel to be converted to private")
+	}
+	if calls := fake.callTokensFor("admin.conversations.convertToPrivate"); !reflect.DeepEqual(calls, []string{"xoxp-admin"}) {
+		t.Errorf("expected one convertToPrivate call with the admin token, got %v", calls)
+	}
+}
+
+func TestResourceSlackChannelVisibilityDiff_withoutAdminToken(t *testing.T) {
+	fake := newFakeSlack(t)
+	meta := fake.meta()
+	r := resourceSlackChannel()
+
+	state := createTestChannel(t, meta, map[string]interface{}{"name": "tf-convert"})
+
+	_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":       "tf-convert",
+		"is_private": true,
+	}), meta)
+	if err == nil || !strings.Contains(err.Error(), "admin_token") {
+		t.Errorf("expected a plan error naming admin_token, got %v", err)
+	}
+
+	// The replaced channel is archived and keeps its name
+	_, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                          "tf-convert",
+		"is_private":                    true,
+		"recreate_on_visibility_change": true,
+	}), meta)
+	if err == nil || !strings.Contains(err.Error(), "new name") {
+		t.Errorf("expected a plan error asking for a new name, got %v", err)
+	}
+
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":                          "tf-convert-private",
+		"is_private":                    true,
+		"recreate_on_visibility_change": true,
+	}), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	if !diff.RequiresNew() {
+		t.Errorf("expected is_private to force replacement")
+	}
+}
+
+func TestSyncChannelMembers_strict(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	fake.addUser("U003", "owner", "")
+	id := fake.addChannel("strict", false, fakeBotUserID, "U001", "U002", "U003")
+
+	diags := syncChannelMembers(context.Background(), fake.meta(), id, []string{"U001"}, true, []string{"U003"})
+	if diags.HasError() {
+		t.Fatalf("unexpected sync error: %v", diags)
+	}
+
+	want := []string{"U001", "U003", fakeBotUserID}
+	if got := fake.channelMembers(id); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+}
+
+func TestSyncChannelMembers_userToken(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	fake.addUser("UADMIN0001", "admin", "")
+	fake.addToken("xoxp-user", "UADMIN0001")
+	id := fake.addChannel("strict", false, fakeBotUserID, "UADMIN0001", "U001", "U002")
+
+	m := fake.meta()
+	m.userClient = fake.clientWithToken("xoxp-user")
+	m.userTokenUserID = "UADMIN0001"
+
+	diags := syncChannelMembers(context.Background(), m, id, []string{"U001", "UADMIN0001"}, true, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected sync error: %v", diags)
+	}
+
+	want := []string{"U001", "UADMIN0001", fakeBotUserID}
+	if got := fake.channelMembers(id); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+	if tokens := fake.callTokensFor("conversations.kick"); !reflect.DeepEqual(tokens, []string{"xoxp-user"}) {
+		t.Errorf("expected conversations.kick to use the user token, got %v", tokens)
+	}
+}
+
+func TestSyncChannelMembers_userTokenUserUndeclared(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	fake.addUser("UADMIN0001", "admin", "")
+	fake.addToken("xoxp-user", "UADMIN0001")
+	id := fake.addChannel("strict", false, fakeBotUserID, "UADMIN0001", "U001", "U002")
+
+	m := fake.meta()
+	m.userClient = fake.clientWithToken("xoxp-user")
+	m.userTokenUserID = "UADMIN0001"
+
+	// The token's own user is an ordinary member and is removed last
+	diags := syncChannelMembers(context.Background(), m, id, []string{"U001"}, true, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected sync error: %v", diags)
+	}
+
+	want := []string{"U001", fakeBotUserID}
+	if got := fake.channelMembers(id); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+	if tokens := fake.callTokensFor("conversations.kick"); !reflect.DeepEqual(tokens, []string{"xoxp-user", "xoxb-fake"}) {
+		t.Errorf("expected U002 to be kicked with the user token and the token user with the bot token, got %v", tokens)
+	}
+}
+
+func TestSyncChannelMembers_userTokenUserNotInChannel(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	fake.addUser("UADMIN0001", "admin", "")
+	fake.addToken("xoxp-user", "UADMIN0001")
+	id := fake.addChannel("strict", false, fakeBotUserID, "U001", "U002")
+
+	m := fake.meta()
+	m.userClient = fake.clientWithToken("xoxp-user")
+	m.userTokenUserID = "UADMIN0001"
+
+	diags := syncChannelMembers(context.Background(), m, id, []string{"U001"}, true, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected sync error: %v", diags)
+	}
+
+	want := []string{"U001", fakeBotUserID}
+	if got := fake.channelMembers(id); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+	if tokens := fake.callTokensFor("conversations.kick"); !reflect.DeepEqual(tokens, []string{"xoxp-user", "xoxb-fake"}) {
+		t.Errorf("expected the kick to fall back to the bot token, got %v", tokens)
+	}
+}
+
+func TestGetChannelMembers_paginates(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.pageSize = 2
+	id := fake.addChannel("large", false, "U001", "U002", "U003", "U004", "U005")
+
+	members, err := getChannelMembers(context.Background(), fake.client(), id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(members) != 5 {
+		t.Errorf("expected 5 members, got %d", len(members))
+	}
+	if calls := fake.callCount("conversations.members"); calls != 3 {
+		t.Errorf("expected 3 conversations.members calls, got %d", calls)
+	}
+}
+
+func TestFindChannelByName_cached(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addChannel("general", false)
+	id := fake.addChannel("random", false)
+	meta := fake.meta()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		ch, _, err := findChannelByName(ctx, meta.cache, "random")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ch == nil || ch.ID != id {
+			t.Fatalf("expected channel %s, got %v", id, ch)
+		}
+	}
+	if calls := fake.callCount("conversations.list"); calls != 1 {
+		t.Errorf("expected 1 conversations.list call, got %d", calls)
+	}
+
+	// Creating a channel invalidates the cached list
+	d := schema.TestResourceDataRaw(t, resourceSlackChannel().Schema, map[string]interface{}{
+		"name": "new-channel",
+	})
+	if diags := resourceSlackChannelCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	ch, _, err := findChannelByName(ctx, meta.cache, "new-channel")
+	if err != nil || ch == nil {
+		t.Fatalf("expected to find the new channel, got %v (%v)", ch, err)
+	}
+}
+
+func TestResourceSlackChannel_unit(t *testing.T) {
+	skipWithoutTerraform(t)
+
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "alice@example.com")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "slack" {
+  token = "xoxb-fake"
+}
+
+resource "slack_channel" "test" {
+  name    = "tf-unit"
+  topic   = "first"
+  members = ["U001"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("slack_channel.test", "id"),
+					resource.TestCheckResourceAttr("slack_channel.test", "topic", "first"),
+					resource.TestCheckResourceAttr("slack_channel.test", "members.#", "1"),
+				),
+			},
+			{
+				Config: `
+provider "slack" {
+  token = "xoxb-fake"
+}
+
+resource "slack_channel" "test" {
+  name    = "tf-unit-renamed"
+  topic   = "second"
+  members = ["U001"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_channel.test", "name", "tf-unit-renamed"),
+					resource.TestCheckResourceAttr("slack_channel.test", "topic", "second"),
+				),
+			},
+		},
+	})
+}
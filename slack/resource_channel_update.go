@@ -7,13 +7,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/slack-go/slack"
 )
 
 // resourceSlackChannelUpdate handles updates to Slack channel resources.
 func resourceSlackChannelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
+	api := m.client
 	channelID := d.Id()
 
 	// Handle name change
@@ -21,52 +21,69 @@ func resourceSlackChannelUpdate(ctx context.Context, d *schema.ResourceData, met
 		newName := d.Get("name").(string)
 		tflog.Info(ctx, fmt.Sprintf("Renaming Slack channel %s to '%s'", channelID, newName))
 
-		_, err := api.RenameConversation(channelID, newName)
+		_, err := api.RenameConversationContext(ctx, channelID, newName)
 		if err != nil {
-			return diag.Errorf("error renaming Slack channel: %s", err)
+			return slackDiagErrorf(err, "conversations.rename", "error renaming Slack channel")
 		}
+		m.cache.InvalidateChannels()
 	}
 
-	// Handle privacy change (Slack does not support this)
+	// Handle privacy change. Without an admin token the plan either
+	// replaces the channel or fails, so this only runs with one.
 	if d.HasChange("is_private") {
-		oldRaw, newRaw := d.GetChange("is_private")
-		oldPrivate := oldRaw.(bool)
-		newPrivate := newRaw.(bool)
+		isPrivate := d.Get("is_private").(bool)
+		method := "admin.conversations.convertToPublic"
+		if isPrivate {
+			method = "admin.conversations.convertToPrivate"
+		}
+
+		admin, err := m.adminAPI(method)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-		if oldPrivate != newPrivate {
-			return diag.Errorf(
-				"Slack does not allow changing 'is_private' via API (Current: %v, Desired: %v). Please change manually in Slack.",
-				oldPrivate, newPrivate,
-			)
+		tflog.Info(ctx, fmt.Sprintf("Converting Slack channel %s (is_private = %v)", channelID, isPrivate))
+		if isPrivate {
+			err = admin.ConvertConversationToPrivateContext(ctx, channelID)
+		} else {
+			err = admin.ConvertConversationToPublicContext(ctx, channelID)
 		}
+		if err != nil {
+			return slackDiagErrorf(err, method, "error converting Slack channel")
+		}
+		m.cache.InvalidateChannels()
 	}
 
 	// Handle member synchronization
-	if d.HasChange("members") {
-		_, newRaw := d.GetChange("members")
-		newMembers := convertSchemaSetToStringSlice(newRaw.(*schema.Set))
+	if d.HasChanges("members", "member_emails", "member_usergroup_ids", "strict_members", "exempt_members") {
+		newMembers, err := desiredChannelMembers(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		strictMembers := d.Get("strict_members").(bool)
+		exemptMembers := convertSchemaSetToStringSlice(d.Get("exempt_members").(*schema.Set))
 
 		tflog.Debug(ctx, fmt.Sprintf("Syncing members for Slack channel %s", channelID))
 
-		memberDiags := syncChannelMembers(api, channelID, newMembers)
+		memberDiags := syncChannelMembers(ctx, m, channelID, newMembers, strictMembers, exemptMembers)
 		diags = append(diags, memberDiags...)
 	}
 
 	if d.HasChange("topic") {
 		newTopic := d.Get("topic").(string)
 		tflog.Debug(ctx, fmt.Sprintf("Updating topic for Slack channel %s to '%s'", channelID, newTopic))
-		_, err := api.SetTopicOfConversation(channelID, newTopic)
+		_, err := api.SetTopicOfConversationContext(ctx, channelID, newTopic)
 		if err != nil {
-			return diag.Errorf("error updating Slack channel topic: %s", err)
+			return slackDiagErrorf(err, "conversations.setTopic", "error updating Slack channel topic")
 		}
 	}
 
 	if d.HasChange("purpose") {
 		newPurpose := d.Get("purpose").(string)
 		tflog.Debug(ctx, fmt.Sprintf("Updating purpose for Slack channel %s to '%s'", channelID, newPurpose))
-		_, err := api.SetPurposeOfConversation(channelID, newPurpose)
+		_, err := api.SetPurposeOfConversationContext(ctx, channelID, newPurpose)
 		if err != nil {
-			return diag.Errorf("error updating Slack channel purpose: %s", err)
+			return slackDiagErrorf(err, "conversations.setPurpose", "error updating Slack channel purpose")
 		}
 	}
 
@@ -3,9 +3,13 @@ package slack
 import (
 	"context"
 	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/slack-go/slack"
 )
@@ -16,6 +20,12 @@ func resourceSlackUsergroup() *schema.Resource {
 		ReadContext:   resourceSlackUsergroupRead,
 		UpdateContext: resourceSlackUsergroupUpdate,
 		DeleteContext: resourceSlackUsergroupDelete,
+		CustomizeDiff: customdiff.Sequence(
+			requireScopes("slack_usergroup"),
+			validateMemberEmailsDiff,
+			resourceSlackUsergroupMembersDiff,
+			resourceSlackUsergroupChannelsDiff,
+		),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -23,9 +33,10 @@ func resourceSlackUsergroup() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"handle": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The handle/mention name for the usergroup (e.g., 'developers' for @developers)",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlackName("usergroup handle", true),
+				Description:      "The handle/mention name for the usergroup (e.g., 'developers' for @developers)",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -41,10 +52,26 @@ func resourceSlackUsergroup() *schema.Resource {
 			"members": {
 				Type:        schema.TypeSet,
 				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "List of user IDs that are members of this usergroup. If neither members nor member_emails is set, the members are not managed and are only read.",
+			},
+			"channels": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Set:         schema.HashString,
-				Description: "List of user IDs that are members of this usergroup",
+				Description: "IDs of the default channels of the usergroup. Members of the usergroup are added to these channels automatically. If unset, the default channels are not managed and are only read.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the usergroup is enabled. Set it to false to disable the usergroup without removing it from Terraform.",
 			},
+			"member_emails": memberEmailsSchema("Email addresses of users that are members of this usergroup. They are resolved to user IDs at plan time, and an address without an active Slack user is an error. Requires the users:read.email scope."),
 			// Computed fields
 			"team_id": {
 				Type:        schema.TypeString,
@@ -56,7 +83,8 @@ func resourceSlackUsergroup() *schema.Resource {
 }
 
 func resourceSlackUsergroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
+	api := m.client
 
 	handle := d.Get("handle").(string)
 	name := d.Get("name").(string)
@@ -65,60 +93,140 @@ func resourceSlackUsergroupCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 	description := d.Get("description").(string)
 
-	tflog.Debug(ctx, fmt.Sprintf("Creating usergroup with handle: %s", handle))
+	channels := convertSchemaSetToStringSlice(d.Get("channels").(*schema.Set))
 
-	// Create the usergroup
-	userGroup := slack.UserGroup{
-		Handle:      handle,
-		Name:        name,
-		Description: description,
-	}
-
-	createdGroup, err := api.CreateUserGroupContext(ctx, userGroup)
+	// Slack cannot delete usergroups, so a group destroyed earlier still
+	// holds its handle and name while disabled. Take it over instead of
+	// failing with name_already_exists, but only if the handle matches.
+	disabled, nameHolder, err := findDisabledUsergroup(ctx, m.cache, handle, name)
 	if err != nil {
-		return diag.Errorf("error creating usergroup: %s", err)
+		return slackDiagErrorf(err, "usergroups.list", "error listing usergroups")
+	}
+	if nameHolder != nil && (disabled == nil || nameHolder.ID != disabled.ID) {
+		return diag.Errorf("cannot create usergroup @%s: the name %q is held by the disabled usergroup %s (@%s). Choose another name, or rename or import that usergroup", handle, name, nameHolder.ID, nameHolder.Handle)
 	}
 
-	d.SetId(createdGroup.ID)
-	tflog.Info(ctx, fmt.Sprintf("Usergroup created with ID: %s", createdGroup.ID))
+	var diags diag.Diagnostics
+	if disabled != nil {
+		tflog.Info(ctx, fmt.Sprintf("Adopting disabled usergroup %s (@%s)", disabled.ID, disabled.Handle))
 
-	// Update members if provided
-	if membersRaw, ok := d.GetOk("members"); ok {
-		members := convertSchemaSetToStringSlice(membersRaw.(*schema.Set))
-		if len(members) > 0 {
-			memberDiags := updateUsergroupMembers(ctx, api, createdGroup.ID, members)
-			if memberDiags.HasError() {
-				return memberDiags
+		enabled := d.Get("enabled").(bool)
+		if enabled {
+			if _, err := api.EnableUserGroupContext(ctx, disabled.ID); err != nil {
+				return slackDiagErrorf(err, "usergroups.enable", "error enabling usergroup %s", disabled.ID)
 			}
 		}
+		// Track the group from here on, so a failed update leaves it in
+		// state instead of orphaning an enabled group
+		d.SetId(disabled.ID)
+
+		detail := fmt.Sprintf("A disabled usergroup with the handle %q already existed (%s). It was updated to match the configuration instead of creating a new one.", handle, disabled.ID)
+		if enabled {
+			detail = fmt.Sprintf("A disabled usergroup with the handle %q already existed (%s). It was re-enabled and updated to match the configuration instead of creating a new one.", handle, disabled.ID)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Adopted disabled usergroup",
+			Detail:   detail,
+		})
+
+		options := []slack.UpdateUserGroupsOption{
+			slack.UpdateUserGroupsOptionName(name),
+			slack.UpdateUserGroupsOptionHandle(handle),
+			slack.UpdateUserGroupsOptionDescription(&description),
+		}
+		if usergroupChannelsManaged(d.GetRawConfig()) {
+			options = append(options, slack.UpdateUserGroupsOptionChannels(channels))
+		}
+		_, err := api.UpdateUserGroupContext(ctx, disabled.ID, options...)
+		if err != nil {
+			return append(diags, slackDiagErrorf(err, "usergroups.update", "error updating usergroup %s", disabled.ID)...)
+		}
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Creating usergroup with handle: %s", handle))
+
+		// Create the usergroup
+		userGroup := slack.UserGroup{
+			Handle:      handle,
+			Name:        name,
+			Description: description,
+			Prefs: slack.UserGroupPrefs{
+				Channels: channels,
+			},
+		}
+
+		createdGroup, err := api.CreateUserGroupContext(ctx, userGroup)
+		if err != nil {
+			return slackDiagErrorf(err, "usergroups.create", "error creating usergroup")
+		}
+
+		d.SetId(createdGroup.ID)
+		tflog.Info(ctx, fmt.Sprintf("Usergroup created with ID: %s", createdGroup.ID))
 	}
 
-	return resourceSlackUsergroupRead(ctx, d, meta)
+	// Update members if provided
+	members, err := desiredMembers(ctx, d, m.cache)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if len(members) > 0 {
+		memberDiags := updateUsergroupMembers(ctx, m, d.Id(), members)
+		if memberDiags.HasError() {
+			return append(diags, memberDiags...)
+		}
+	}
+
+	// A newly created group starts enabled
+	if disabled == nil && !d.Get("enabled").(bool) {
+		if _, err := api.DisableUserGroupContext(ctx, d.Id()); err != nil {
+			return append(diags, slackDiagErrorf(err, "usergroups.disable", "error disabling usergroup %s", d.Id())...)
+		}
+	}
+
+	m.cache.InvalidateUsergroups()
+	return append(diags, resourceSlackUsergroupRead(ctx, d, meta)...)
+}
+
+// findDisabledUsergroup returns the disabled usergroups holding handle and
+// name. Either is nil if no disabled usergroup holds it.
+func findDisabledUsergroup(ctx context.Context, cache *workspaceCache, handle, name string) (byHandle, byName *slack.UserGroup, err error) {
+	usergroups, err := cache.Usergroups(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range usergroups {
+		if usergroups[i].DateDelete == 0 {
+			continue
+		}
+		if usergroups[i].Handle == handle {
+			byHandle = &usergroups[i]
+		}
+		if usergroups[i].Name == name {
+			byName = &usergroups[i]
+		}
+	}
+	return byHandle, byName, nil
 }
 
 func resourceSlackUsergroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
+	api := m.client
 	usergroupID := d.Id()
 
 	tflog.Debug(ctx, fmt.Sprintf("Reading usergroup: %s", usergroupID))
 
-	// Get all usergroups and find ours
-	usergroups, err := api.GetUserGroupsContext(ctx,
-		slack.GetUserGroupsOptionIncludeUsers(true),
-		slack.GetUserGroupsOptionIncludeDisabled(true),
-	)
+	usergroup, err := m.cache.UsergroupByID(ctx, usergroupID)
 	if err != nil {
-		return diag.Errorf("error reading usergroups: %s", err)
+		return slackDiagErrorf(err, "usergroups.list", "error reading usergroups")
 	}
-
-	var usergroup *slack.UserGroup
-	for i := range usergroups {
-		if usergroups[i].ID == usergroupID {
-			usergroup = &usergroups[i]
-			break
+	if usergroup == nil {
+		// The index may predate the group; refetch it once before giving up
+		m.cache.InvalidateUsergroups()
+		usergroup, err = m.cache.UsergroupByID(ctx, usergroupID)
+		if err != nil {
+			return slackDiagErrorf(err, "usergroups.list", "error reading usergroups")
 		}
 	}
-
 	if usergroup == nil {
 		tflog.Warn(ctx, fmt.Sprintf("Usergroup %s not found, removing from state", usergroupID))
 		d.SetId("")
@@ -138,26 +246,65 @@ func resourceSlackUsergroupRead(ctx context.Context, d *schema.ResourceData, met
 	if err := d.Set("team_id", usergroup.TeamID); err != nil {
 		return diag.Errorf("error setting team_id: %s", err)
 	}
+	if err := d.Set("channels", usergroup.Prefs.Channels); err != nil {
+		return diag.Errorf("error setting channels: %s", err)
+	}
+	if err := d.Set("enabled", usergroup.DateDelete == 0); err != nil {
+		return diag.Errorf("error setting enabled: %s", err)
+	}
+
+	// slack-go cannot ask usergroups.users.list for the members of a disabled
+	// group, so they stay as recorded in state until it is enabled again
+	if usergroup.DateDelete != 0 {
+		return nil
+	}
+
+	// usergroups.users.list returns every member in one response; Slack
+	// documents no cursor for it and slack-go exposes none, so there is no
+	// pagination to follow
+	members, err := api.GetUserGroupMembersContext(ctx, usergroupID)
+	if err != nil {
+		return slackDiagErrorf(err, "usergroups.users.list", "error reading usergroup members")
+	}
 
-	// Set members
-	if len(usergroup.Users) > 0 {
-		membersSet := schema.NewSet(schema.HashString, convertStringSliceToInterface(usergroup.Users))
-		if err := d.Set("members", membersSet); err != nil {
-			return diag.Errorf("error setting members: %s", err)
+	// Set members even when the group is empty, so that managed members
+	// drift back to the configuration. Users declared only through
+	// member_emails are tracked there.
+	emailOnly, err := readMemberEmails(ctx, d, m.cache, members)
+	if err != nil {
+		return diag.Errorf("error reading member_emails: %s", err)
+	}
+	users := make([]string, 0, len(members))
+	for _, user := range members {
+		if !emailOnly[user] {
+			users = append(users, user)
 		}
 	}
+	membersSet := schema.NewSet(schema.HashString, convertStringSliceToInterface(users))
+	if err := d.Set("members", membersSet); err != nil {
+		return diag.Errorf("error setting members: %s", err)
+	}
 
 	return nil
 }
 
 func resourceSlackUsergroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
+	api := m.client
 	usergroupID := d.Id()
 
 	tflog.Debug(ctx, fmt.Sprintf("Updating usergroup: %s", usergroupID))
 
-	// Update name, handle, or description if changed
-	if d.HasChanges("name", "handle", "description") {
+	// Enable the usergroup before changing it
+	if d.HasChange("enabled") && d.Get("enabled").(bool) {
+		if _, err := api.EnableUserGroupContext(ctx, usergroupID); err != nil {
+			return slackDiagErrorf(err, "usergroups.enable", "error enabling usergroup")
+		}
+		tflog.Info(ctx, "Usergroup enabled successfully")
+	}
+
+	// Update name, handle, description or channels if changed
+	if d.HasChanges("name", "handle", "description", "channels") {
 		var options []slack.UpdateUserGroupsOption
 
 		if d.HasChange("name") {
@@ -172,65 +319,146 @@ func resourceSlackUsergroupUpdate(ctx context.Context, d *schema.ResourceData, m
 			description := d.Get("description").(string)
 			options = append(options, slack.UpdateUserGroupsOptionDescription(&description))
 		}
+		if d.HasChange("channels") && usergroupChannelsManaged(d.GetRawConfig()) {
+			channels := convertSchemaSetToStringSlice(d.Get("channels").(*schema.Set))
+			options = append(options, slack.UpdateUserGroupsOptionChannels(channels))
+		}
 
 		_, err := api.UpdateUserGroupContext(ctx, usergroupID, options...)
 		if err != nil {
-			return diag.Errorf("error updating usergroup: %s", err)
+			return slackDiagErrorf(err, "usergroups.update", "error updating usergroup")
 		}
 		tflog.Info(ctx, "Usergroup metadata updated successfully")
 	}
 
-	// Update members if changed
-	if d.HasChange("members") {
-		var members []string
-		if membersRaw, ok := d.GetOk("members"); ok {
-			members = convertSchemaSetToStringSlice(membersRaw.(*schema.Set))
+	// Update members if changed and still managed
+	if d.HasChanges("members", "member_emails") && usergroupMembersManaged(d.GetRawConfig()) {
+		members, err := desiredMembers(ctx, d, m.cache)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 
-		memberDiags := updateUsergroupMembers(ctx, api, usergroupID, members)
+		memberDiags := updateUsergroupMembers(ctx, m, usergroupID, members)
 		if memberDiags.HasError() {
 			return memberDiags
 		}
 	}
 
+	// Disable the usergroup after all other changes
+	if d.HasChange("enabled") && !d.Get("enabled").(bool) {
+		if _, err := api.DisableUserGroupContext(ctx, usergroupID); err != nil {
+			return slackDiagErrorf(err, "usergroups.disable", "error disabling usergroup")
+		}
+		tflog.Info(ctx, "Usergroup disabled successfully")
+	}
+
+	if d.HasChanges("name", "handle", "description", "channels", "enabled") {
+		m.cache.InvalidateUsergroups()
+	}
+
 	return resourceSlackUsergroupRead(ctx, d, meta)
 }
 
 func resourceSlackUsergroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	api := meta.(*slack.Client)
+	m := meta.(*providerMeta)
+	api := m.client
 	usergroupID := d.Id()
 
+	// A usergroup disabled through enabled = false needs no further action
+	if !d.Get("enabled").(bool) {
+		d.SetId("")
+		return nil
+	}
+
 	tflog.Info(ctx, fmt.Sprintf("Disabling usergroup: %s", usergroupID))
 
 	// Slack doesn't allow deleting usergroups, only disabling them
 	_, err := api.DisableUserGroupContext(ctx, usergroupID)
 	if err != nil {
-		return diag.Errorf("error disabling usergroup: %s", err)
+		return slackDiagErrorf(err, "usergroups.disable", "error disabling usergroup")
 	}
+	m.cache.InvalidateUsergroups()
 
 	d.SetId("")
 	tflog.Info(ctx, "Usergroup disabled successfully")
 	return nil
 }
 
-// Helper function to update usergroup members
-func updateUsergroupMembers(ctx context.Context, api *slack.Client, usergroupID string, members []string) diag.Diagnostics {
-	tflog.Debug(ctx, fmt.Sprintf("Updating members for usergroup %s with %d members", usergroupID, len(members)))
+// usergroupMembersManaged reports whether the configuration sets members or
+// member_emails. Without either, the usergroup's members are left alone.
+func usergroupMembersManaged(config cty.Value) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	return !config.GetAttr("members").IsNull() || !config.GetAttr("member_emails").IsNull()
+}
 
-	// Join members with comma (Slack API expects comma-separated string)
-	membersStr := ""
-	if len(members) > 0 {
-		for i, member := range members {
-			if i > 0 {
-				membersStr += ","
-			}
-			membersStr += member
+// usergroupChannelsManaged reports whether the configuration sets channels.
+// Without it, default channels set in Slack are left alone.
+func usergroupChannelsManaged(config cty.Value) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	return !config.GetAttr("channels").IsNull()
+}
+
+// resourceSlackUsergroupChannelsDiff plans an explicit channels = [] as
+// clearing the default channels, which the computed attribute would
+// otherwise hide.
+func resourceSlackUsergroupChannelsDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	v := config.GetAttr("channels")
+	if v.IsKnown() && !v.IsNull() && v.LengthInt() == 0 {
+		if old, _ := d.GetChange("channels"); old.(*schema.Set).Len() > 0 {
+			return d.SetNew("channels", []interface{}{})
+		}
+	}
+	return nil
+}
+
+// resourceSlackUsergroupMembersDiff rejects an explicitly empty members or
+// member_emails, since Slack does not allow a usergroup without members. When
+// only member_emails is set, members is planned as empty so that users added
+// outside Terraform are removed.
+func resourceSlackUsergroupMembersDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	for _, key := range []string{"members", "member_emails"} {
+		v := config.GetAttr(key)
+		if v.IsKnown() && !v.IsNull() && v.LengthInt() == 0 {
+			return cty.GetAttrPath(key).NewErrorf("a usergroup must have at least one member, so %s cannot be empty. Remove %s to stop managing the members, or set enabled = false to disable the usergroup", key, key)
+		}
+	}
+
+	if config.GetAttr("members").IsNull() && !config.GetAttr("member_emails").IsNull() {
+		if old, _ := d.GetChange("members"); old.(*schema.Set).Len() > 0 {
+			return d.SetNew("members", []interface{}{})
 		}
 	}
+	return nil
+}
+
+// updateUsergroupMembers replaces the members of a usergroup. Slack rejects
+// an empty list, so members must not be empty.
+func updateUsergroupMembers(ctx context.Context, m *providerMeta, usergroupID string, members []string) diag.Diagnostics {
+	tflog.Debug(ctx, fmt.Sprintf("Updating members for usergroup %s with %d members", usergroupID, len(members)))
+
+	if len(members) == 0 {
+		return diag.Errorf("error updating usergroup members: a usergroup must have at least one member")
+	}
 
-	_, err := api.UpdateUserGroupMembersContext(ctx, usergroupID, membersStr)
+	// Slack expects a comma-separated list
+	_, err := m.client.UpdateUserGroupMembersContext(ctx, usergroupID, strings.Join(members, ","))
+	m.cache.InvalidateUsergroupMembers(usergroupID)
 	if err != nil {
-		return diag.Errorf("error updating usergroup members: %s", err)
+		return slackDiagErrorf(err, "usergroups.users.update", "error updating usergroup members")
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Usergroup members updated successfully (%d members)", len(members)))
@@ -0,0 +1,190 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const usergroupMemberMaxAttempts = 5
+
+// usergroupMemberRetryBackoff is the wait before the first retry of a
+// usergroup member update that was overwritten concurrently. It grows
+// linearly with each attempt.
+var usergroupMemberRetryBackoff = time.Second
+
+// errUsergroupWouldBeEmpty is returned when removing a member would leave a
+// usergroup without members, which Slack rejects.
+var errUsergroupWouldBeEmpty = errors.New("usergroup would be left without members")
+
+// resourceSlackUsergroupMember manages a single user's membership of a
+// usergroup. Slack only accepts the whole member list, so every change is a
+// locked read-modify-write of that list.
+func resourceSlackUsergroupMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSlackUsergroupMemberCreate,
+		ReadContext:   resourceSlackUsergroupMemberRead,
+		DeleteContext: resourceSlackUsergroupMemberDelete,
+		CustomizeDiff: requireScopes("slack_usergroup_member"),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"usergroup_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the usergroup.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the user to add to the usergroup.",
+			},
+		},
+	}
+}
+
+// parseUsergroupMemberID splits a "S123:U456" resource ID.
+func parseUsergroupMemberID(id string) (usergroupID, userID string, err error) {
+	return parseMemberID("slack_usergroup_member", "usergroup_id", id)
+}
+
+func resourceSlackUsergroupMemberCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	usergroupID := d.Get("usergroup_id").(string)
+	userID := d.Get("user_id").(string)
+
+	tflog.Debug(ctx, fmt.Sprintf("Adding user %s to usergroup %s", userID, usergroupID))
+
+	if err := modifyUsergroupMembers(ctx, m, usergroupID, userID, true); err != nil {
+		return slackDiagErrorf(err, "usergroups.users.update", "error adding user %s to usergroup %s", userID, usergroupID)
+	}
+
+	d.SetId(memberID(usergroupID, userID))
+	return resourceSlackUsergroupMemberRead(ctx, d, meta)
+}
+
+func resourceSlackUsergroupMemberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	api := meta.(*providerMeta).client
+
+	usergroupID, userID, err := parseUsergroupMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	members, err := api.GetUserGroupMembersContext(ctx, usergroupID)
+	if err != nil {
+		if isSlackNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Usergroup %s not found, removing membership %s from state", usergroupID, d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return slackDiagErrorf(err, "usergroups.users.list", "error reading members of usergroup %s", usergroupID)
+	}
+
+	if !hasMember(members, userID) {
+		tflog.Warn(ctx, fmt.Sprintf("User %s is no longer in usergroup %s, removing from state", userID, usergroupID))
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("usergroup_id", usergroupID); err != nil {
+		return diag.Errorf("error setting 'usergroup_id': %s", err)
+	}
+	if err := d.Set("user_id", userID); err != nil {
+		return diag.Errorf("error setting 'user_id': %s", err)
+	}
+	return nil
+}
+
+func resourceSlackUsergroupMemberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	usergroupID, userID, err := parseUsergroupMemberID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Removing user %s from usergroup %s", userID, usergroupID))
+
+	err = modifyUsergroupMembers(ctx, m, usergroupID, userID, false)
+	switch {
+	case errors.Is(err, errUsergroupWouldBeEmpty):
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "User left in usergroup",
+				Detail:   fmt.Sprintf("User %s is the last member of usergroup %s. Slack does not allow a usergroup without members, so the user was left in place and only removed from the Terraform state. Disable the usergroup to remove it.", userID, usergroupID),
+			},
+		}
+	case isSlackNotFound(err):
+		return nil
+	case err != nil:
+		return slackDiagErrorf(err, "usergroups.users.update", "error removing user %s from usergroup %s", userID, usergroupID)
+	}
+	return nil
+}
+
+// modifyUsergroupMembers adds userID to, or removes it from, the members of
+// a usergroup. Slack can only replace the whole member list, so the current
+// list is read, changed and written back. Updates from this provider
+// instance are serialized per usergroup; to catch writers elsewhere, the
+// list is read again after writing and the update is retried if a
+// concurrent write dropped the change.
+func modifyUsergroupMembers(ctx context.Context, m *providerMeta, usergroupID, userID string, add bool) error {
+	unlock := m.usergroupLocks.Lock(usergroupID)
+	defer unlock()
+
+	api := m.client
+	for attempt := 0; attempt < usergroupMemberMaxAttempts; attempt++ {
+		if attempt > 0 {
+			wait := time.Duration(attempt) * usergroupMemberRetryBackoff
+			tflog.Debug(ctx, fmt.Sprintf("Members of usergroup %s were modified concurrently, retrying in %s (attempt %d of %d)", usergroupID, wait, attempt+1, usergroupMemberMaxAttempts))
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+
+		members, err := api.GetUserGroupMembersContext(ctx, usergroupID)
+		if err != nil {
+			return err
+		}
+		if hasMember(members, userID) == add {
+			return nil
+		}
+
+		if add {
+			members = append(members, userID)
+		} else {
+			members = removeMember(members, userID)
+			if len(members) == 0 {
+				return errUsergroupWouldBeEmpty
+			}
+		}
+		_, err = api.UpdateUserGroupMembersContext(ctx, usergroupID, strings.Join(members, ","))
+		m.cache.InvalidateUsergroupMembers(usergroupID)
+		if err != nil {
+			return err
+		}
+
+		members, err = api.GetUserGroupMembersContext(ctx, usergroupID)
+		if err != nil {
+			return err
+		}
+		if hasMember(members, userID) == add {
+			return nil
+		}
+	}
+	return fmt.Errorf("members of usergroup %s kept changing concurrently, gave up after %d attempts", usergroupID, usergroupMemberMaxAttempts)
+}
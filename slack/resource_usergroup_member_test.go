@@ -0,0 +1,127 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/slack-go/slack"
+)
+
+func usergroupMemberData(t *testing.T, usergroupID, userID string) *schema.ResourceData {
+	t.Helper()
+	return schema.TestResourceDataRaw(t, resourceSlackUsergroupMember().Schema, map[string]interface{}{
+		"usergroup_id": usergroupID,
+		"user_id":      userID,
+	})
+}
+
+func TestResourceSlackUsergroupMember_concurrentAdds(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U000", "owner", "")
+	group := fake.addUsergroup("devs", "U000")
+	meta := fake.meta()
+
+	// Build the ResourceData up front: TestResourceDataRaw may call
+	// t.Fatalf, which must not run off the test goroutine
+	want := []string{"U000"}
+	data := make(map[string]*schema.ResourceData)
+	for i := 1; i <= 10; i++ {
+		id := fmt.Sprintf("U%03d", i)
+		fake.addUser(id, id, "")
+		want = append(want, id)
+		data[id] = usergroupMemberData(t, group, id)
+	}
+
+	var wg sync.WaitGroup
+	for id, d := range data {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if diags := resourceSlackUsergroupMemberCreate(context.Background(), d, meta); diags.HasError() {
+				t.Errorf("unexpected create error for %s: %v", id, diags)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got := append([]string{}, fake.usergroup(group).Users...)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected members %v, got %v", want, got)
+	}
+}
+
+// clobberingClient simulates another writer that restores the previous
+// member list right after the first usergroups.users.update.
+type clobberingClient struct {
+	slackClient
+	clobbered bool
+}
+
+func (c *clobberingClient) UpdateUserGroupMembersContext(ctx context.Context, userGroup, members string) (slack.UserGroup, error) {
+	previous, err := c.GetUserGroupMembersContext(ctx, userGroup)
+	if err != nil {
+		return slack.UserGroup{}, err
+	}
+	ug, err := c.slackClient.UpdateUserGroupMembersContext(ctx, userGroup, members)
+	if err == nil && !c.clobbered {
+		c.clobbered = true
+		return c.slackClient.UpdateUserGroupMembersContext(ctx, userGroup, strings.Join(previous, ","))
+	}
+	return ug, err
+}
+
+func TestResourceSlackUsergroupMember_retriesConcurrentModification(t *testing.T) {
+	backoff := usergroupMemberRetryBackoff
+	usergroupMemberRetryBackoff = time.Millisecond
+	t.Cleanup(func() { usergroupMemberRetryBackoff = backoff })
+
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	group := fake.addUsergroup("devs", "U001")
+	meta := newProviderMeta(&clobberingClient{slackClient: fake.client()})
+
+	d := usergroupMemberData(t, group, "U002")
+	if diags := resourceSlackUsergroupMemberCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	if got := fake.usergroup(group).Users; !reflect.DeepEqual(got, []string{"U001", "U002"}) {
+		t.Errorf("expected the overwritten update to be retried, got members %v", got)
+	}
+	if calls := fake.callCount("usergroups.users.update"); calls != 3 {
+		t.Errorf("expected 3 usergroups.users.update calls (write, clobber, retry), got %d", calls)
+	}
+}
+
+func TestResourceSlackUsergroupMember_lastMember(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	group := fake.addUsergroup("devs", "U001", "U002")
+	ctx := context.Background()
+	meta := fake.meta()
+
+	d := resourceSlackUsergroupMember().Data(nil)
+	d.SetId(group + ":U002")
+	if diags := resourceSlackUsergroupMemberDelete(ctx, d, meta); diags.HasError() || len(diags) != 0 {
+		t.Fatalf("unexpected delete diagnostics: %v", diags)
+	}
+
+	d.SetId(group + ":U001")
+	diags := resourceSlackUsergroupMemberDelete(ctx, d, meta)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning when removing the last member, got %v", diags)
+	}
+	if got := fake.usergroup(group).Users; !reflect.DeepEqual(got, []string{"U001"}) {
+		t.Errorf("expected the last member to stay, got %v", got)
+	}
+}
@@ -0,0 +1,642 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/slack-go/slack"
+)
+
+func TestResourceSlackUsergroupLifecycle(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	ctx := context.Background()
+	meta := fake.meta()
+
+	d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{
+		"handle":      "devs",
+		"description": "Developers",
+		"members":     []interface{}{"U001", "U002"},
+	})
+	if diags := resourceSlackUsergroupCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+
+	ug := fake.usergroup(d.Id())
+	if ug == nil {
+		t.Fatalf("usergroup %s was not created", d.Id())
+	}
+	if ug.Name != "devs" {
+		t.Errorf("expected name to default to handle, got %q", ug.Name)
+	}
+	members := append([]string{}, ug.Users...)
+	sort.Strings(members)
+	if want := []string{"U001", "U002"}; !reflect.DeepEqual(members, want) {
+		t.Errorf("expected members %v, got %v", want, members)
+	}
+	if got := d.Get("team_id").(string); got != fakeTeamID {
+		t.Errorf("expected team_id %s, got %s", fakeTeamID, got)
+	}
+
+	if diags := resourceSlackUsergroupDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected delete error: %v", diags)
+	}
+	if fake.usergroup(ug.ID).DateDelete == 0 {
+		t.Errorf("expected usergroup to be disabled")
+	}
+}
+
+func TestResourceSlackUsergroup_memberEmails(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "alice@example.com")
+	fake.addUser("U002", "bob", "bob@example.com")
+	ctx := context.Background()
+	meta := fake.meta()
+
+	d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{
+		"handle":        "devs",
+		"members":       []interface{}{"U001"},
+		"member_emails": []interface{}{"bob@example.com"},
+	})
+	if diags := resourceSlackUsergroupCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+
+	members := append([]string{}, fake.usergroup(d.Id()).Users...)
+	sort.Strings(members)
+	if want := []string{"U001", "U002"}; !reflect.DeepEqual(members, want) {
+		t.Errorf("expected members %v, got %v", want, members)
+	}
+	if got := convertSchemaSetToStringSlice(d.Get("members").(*schema.Set)); !reflect.DeepEqual(got, []string{"U001"}) {
+		t.Errorf("expected users added by email to stay out of members, got %v", got)
+	}
+	if got := convertSchemaSetToStringSlice(d.Get("member_emails").(*schema.Set)); !reflect.DeepEqual(got, []string{"bob@example.com"}) {
+		t.Errorf("expected member_emails to be kept, got %v", got)
+	}
+}
+
+func TestResourceSlackUsergroup_channels(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	general := fake.addChannel("general", false)
+	onboarding := fake.addChannel("onboarding", false)
+	ctx := context.Background()
+	meta := fake.meta()
+	r := resourceSlackUsergroup()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"handle":   "new-hires",
+		"members":  []interface{}{"U001"},
+		"channels": []interface{}{general},
+	})
+	if diags := resourceSlackUsergroupCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	if got := fake.usergroup(d.Id()).Prefs.Channels; !reflect.DeepEqual(got, []string{general}) {
+		t.Errorf("expected default channels [%s], got %v", general, got)
+	}
+	if got := convertSchemaSetToStringSlice(d.Get("channels").(*schema.Set)); !reflect.DeepEqual(got, []string{general}) {
+		t.Errorf("expected channels to be read back, got %v", got)
+	}
+
+	state := d.State()
+	apply := func(cfg map[string]interface{}) {
+		t.Helper()
+		state.RawConfig = testRawConfig(r, cfg)
+		diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+		if err != nil {
+			t.Fatalf("unexpected plan error: %s", err)
+		}
+		if diff == nil {
+			return
+		}
+		var diags diag.Diagnostics
+		state, diags = r.Apply(ctx, state, diff, meta)
+		if diags.HasError() {
+			t.Fatalf("unexpected apply error: %v", diags)
+		}
+	}
+
+	apply(map[string]interface{}{
+		"handle":   "new-hires",
+		"members":  []interface{}{"U001"},
+		"channels": []interface{}{onboarding},
+	})
+	if got := fake.usergroup(d.Id()).Prefs.Channels; !reflect.DeepEqual(got, []string{onboarding}) {
+		t.Errorf("expected default channels [%s], got %v", onboarding, got)
+	}
+
+	// Without channels in the configuration they are left alone
+	apply(map[string]interface{}{
+		"handle":      "new-hires",
+		"members":     []interface{}{"U001"},
+		"description": "First month",
+	})
+	if got := fake.usergroup(d.Id()).Prefs.Channels; !reflect.DeepEqual(got, []string{onboarding}) {
+		t.Errorf("expected unmanaged default channels to be kept, got %v", got)
+	}
+
+	// An explicit empty set clears them
+	apply(map[string]interface{}{
+		"handle":      "new-hires",
+		"members":     []interface{}{"U001"},
+		"description": "First month",
+		"channels":    []interface{}{},
+	})
+	if got := fake.usergroup(d.Id()).Prefs.Channels; len(got) != 0 {
+		t.Errorf("expected default channels to be cleared, got %v", got)
+	}
+}
+
+func TestResourceSlackUsergroup_adoptDisabledKeepsChannels(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	general := fake.addChannel("general", false)
+	ctx := context.Background()
+	meta := fake.meta()
+	r := resourceSlackUsergroup()
+
+	id := fake.addUsergroup("devs", "U001")
+	fake.mu.Lock()
+	fake.usergroups[id].DateDelete = 1
+	fake.usergroups[id].Prefs.Channels = []string{general}
+	fake.mu.Unlock()
+
+	cfg := map[string]interface{}{"handle": "devs"}
+	state := &terraform.InstanceState{RawConfig: testRawConfig(r, cfg)}
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	state, diags := r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	if state.ID != id {
+		t.Fatalf("expected usergroup %s to be adopted, got %s", id, state.ID)
+	}
+	if got := fake.usergroup(id).Prefs.Channels; !reflect.DeepEqual(got, []string{general}) {
+		t.Errorf("expected unmanaged default channels to be kept, got %v", got)
+	}
+}
+
+func TestResourceSlackUsergroup_adoptDisabled(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	ctx := context.Background()
+	meta := fake.meta()
+
+	id := fake.addUsergroup("devs", "U001")
+	fake.usergroup(id).DateDelete = 1
+
+	d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{
+		"handle":      "devs",
+		"description": "Developers",
+		"members":     []interface{}{"U002"},
+	})
+	diags := resourceSlackUsergroupCreate(ctx, d, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected an adoption warning, got %v", diags)
+	}
+	if d.Id() != id {
+		t.Fatalf("expected the disabled usergroup %s to be adopted, got %s", id, d.Id())
+	}
+	if fake.callCount("usergroups.create") != 0 {
+		t.Errorf("expected no usergroups.create call")
+	}
+
+	ug := fake.usergroup(id)
+	if ug.DateDelete != 0 {
+		t.Errorf("expected the usergroup to be enabled")
+	}
+	if ug.Description != "Developers" {
+		t.Errorf("expected description to be reconciled, got %q", ug.Description)
+	}
+	if want := []string{"U002"}; !reflect.DeepEqual(ug.Users, want) {
+		t.Errorf("expected members %v, got %v", want, ug.Users)
+	}
+	if !d.Get("enabled").(bool) {
+		t.Errorf("expected enabled to be true")
+	}
+}
+
+func TestResourceSlackUsergroup_adoptDisabledNameOnly(t *testing.T) {
+	fake := newFakeSlack(t)
+	ctx := context.Background()
+	meta := fake.meta()
+
+	id := fake.addUsergroup("old-devs")
+	fake.mu.Lock()
+	fake.usergroups[id].Name = "Developers"
+	fake.usergroups[id].DateDelete = 1
+	fake.mu.Unlock()
+
+	d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{
+		"handle": "devs",
+		"name":   "Developers",
+	})
+	diags := resourceSlackUsergroupCreate(ctx, d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, id) {
+		t.Fatalf("expected an error naming the disabled usergroup %s, got %v", id, diags)
+	}
+	if ug := fake.usergroup(id); ug.Handle != "old-devs" || ug.DateDelete == 0 {
+		t.Errorf("expected the disabled usergroup to be left alone, got @%s (disabled: %v)", ug.Handle, ug.DateDelete != 0)
+	}
+}
+
+// failingUpdateClient fails every usergroups.update call.
+type failingUpdateClient struct {
+	slackClient
+}
+
+func (c *failingUpdateClient) UpdateUserGroupContext(ctx context.Context, userGroupID string, options ...slack.UpdateUserGroupsOption) (slack.UserGroup, error) {
+	return slack.UserGroup{}, slack.SlackErrorResponse{Err: "invalid_arguments"}
+}
+
+func TestResourceSlackUsergroup_adoptDisabledUpdateFails(t *testing.T) {
+	fake := newFakeSlack(t)
+	ctx := context.Background()
+	meta := fake.meta()
+	meta.client = &failingUpdateClient{slackClient: meta.client}
+
+	id := fake.addUsergroup("devs")
+	fake.usergroup(id).DateDelete = 1
+
+	d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{
+		"handle": "devs",
+	})
+	if diags := resourceSlackUsergroupCreate(ctx, d, meta); !diags.HasError() {
+		t.Fatalf("expected the failed update to be reported")
+	}
+	if d.Id() != id {
+		t.Errorf("expected the enabled usergroup %s to stay in state, got %q", id, d.Id())
+	}
+}
+
+func TestResourceSlackUsergroup_adoptDisabledKeepDisabled(t *testing.T) {
+	fake := newFakeSlack(t)
+	ctx := context.Background()
+	meta := fake.meta()
+
+	id := fake.addUsergroup("devs")
+	fake.usergroup(id).DateDelete = 1
+
+	d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{
+		"handle":  "devs",
+		"enabled": false,
+	})
+	diags := resourceSlackUsergroupCreate(ctx, d, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	if fake.usergroup(id).DateDelete == 0 {
+		t.Errorf("expected the usergroup to stay disabled")
+	}
+	if len(diags) != 1 || strings.Contains(diags[0].Detail, "re-enabled") {
+		t.Errorf("expected a warning that does not claim the usergroup was re-enabled, got %v", diags)
+	}
+}
+
+func TestResourceSlackUsergroup_enabled(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	ctx := context.Background()
+	meta := fake.meta()
+	r := resourceSlackUsergroup()
+
+	cfg := map[string]interface{}{
+		"handle":  "devs",
+		"members": []interface{}{"U001"},
+		"enabled": false,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	if diags := resourceSlackUsergroupCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+	if fake.usergroup(d.Id()).DateDelete == 0 {
+		t.Errorf("expected the usergroup to be created disabled")
+	}
+	if d.Get("enabled").(bool) {
+		t.Errorf("expected enabled to be read back as false")
+	}
+
+	// Enable it
+	cfg["enabled"] = true
+	state := d.State()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	state, diags := r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	if fake.usergroup(d.Id()).DateDelete != 0 {
+		t.Errorf("expected the usergroup to be enabled")
+	}
+
+	// Disable it again; destroying it afterwards needs no further call
+	cfg["enabled"] = false
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	state, diags = r.Apply(ctx, state, diff, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	if fake.usergroup(d.Id()).DateDelete == 0 {
+		t.Errorf("expected the usergroup to be disabled")
+	}
+
+	disables := fake.callCount("usergroups.disable")
+	if _, diags := r.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, meta); diags.HasError() {
+		t.Fatalf("unexpected destroy error: %v", diags)
+	}
+	if got := fake.callCount("usergroups.disable"); got != disables {
+		t.Errorf("expected no usergroups.disable call on destroy, got %d", got-disables)
+	}
+}
+
+func TestResourceSlackUsergroupRead_cached(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	ids := []string{
+		fake.addUsergroup("devs", "U001"),
+		fake.addUsergroup("ops", "U002"),
+		fake.addUsergroup("leads", "U001", "U002"),
+	}
+	ctx := context.Background()
+	meta := fake.meta()
+
+	for _, id := range ids {
+		d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{})
+		d.SetId(id)
+		if diags := resourceSlackUsergroupRead(ctx, d, meta); diags.HasError() {
+			t.Fatalf("unexpected read error: %v", diags)
+		}
+		members := convertSchemaSetToStringSlice(d.Get("members").(*schema.Set))
+		sort.Strings(members)
+		if want := fake.usergroup(id).Users; !reflect.DeepEqual(members, want) {
+			t.Errorf("expected members %v for %s, got %v", want, id, members)
+		}
+	}
+	if calls := fake.callCount("usergroups.list"); calls != 1 {
+		t.Errorf("expected 1 usergroups.list call, got %d", calls)
+	}
+	if calls := fake.callCount("usergroups.users.list"); calls != len(ids) {
+		t.Errorf("expected %d usergroups.users.list calls, got %d", len(ids), calls)
+	}
+
+	// A usergroup missing from the index is looked up again once
+	id := fake.addUsergroup("new-hires", "U001")
+	d := schema.TestResourceDataRaw(t, resourceSlackUsergroup().Schema, map[string]interface{}{})
+	d.SetId(id)
+	if diags := resourceSlackUsergroupRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected read error: %v", diags)
+	}
+	if d.Id() != id || d.Get("handle").(string) != "new-hires" {
+		t.Errorf("expected usergroup %s to be read, got %q (%q)", id, d.Id(), d.Get("handle"))
+	}
+	if calls := fake.callCount("usergroups.list"); calls != 2 {
+		t.Errorf("expected 2 usergroups.list calls, got %d", calls)
+	}
+}
+
+func TestResourceSlackUsergroup_emptyMembers(t *testing.T) {
+	fake := newFakeSlack(t)
+	meta := fake.meta()
+	r := resourceSlackUsergroup()
+
+	for _, key := range []string{"members", "member_emails"} {
+		cfg := map[string]interface{}{
+			"handle": "devs",
+			key:      []interface{}{},
+		}
+		state := &terraform.InstanceState{RawConfig: testRawConfig(r, cfg)}
+		_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), meta)
+		if err == nil {
+			t.Fatalf("expected an error for an empty %s", key)
+		}
+		var pathErr cty.PathError
+		if !errors.As(err, &pathErr) || !pathErr.Path.Equals(cty.GetAttrPath(key)) {
+			t.Errorf("expected the error to point at %s, got %v", key, err)
+		}
+	}
+}
+
+func TestResourceSlackUsergroup_memberDrift(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	ctx := context.Background()
+	meta := fake.meta()
+	r := resourceSlackUsergroup()
+
+	cfg := map[string]interface{}{
+		"handle":  "devs",
+		"members": []interface{}{"U001"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	if diags := resourceSlackUsergroupCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected create error: %v", diags)
+	}
+
+	// Someone empties the usergroup outside Terraform
+	fake.mu.Lock()
+	fake.usergroups[d.Id()].Users = []string{}
+	fake.mu.Unlock()
+
+	state, diags := r.RefreshWithoutUpgrade(ctx, d.State(), meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected refresh error: %v", diags)
+	}
+	if got := state.Attributes["members.#"]; got != "0" {
+		t.Errorf("expected the emptied usergroup to be read as empty, got %s members", got)
+	}
+
+	state.RawConfig = testRawConfig(r, cfg)
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	if diff.Empty() {
+		t.Fatalf("expected a diff restoring the members")
+	}
+	if _, diags := r.Apply(ctx, state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	if got := fake.usergroup(d.Id()).Users; !reflect.DeepEqual(got, []string{"U001"}) {
+		t.Errorf("expected members to be restored, got %v", got)
+	}
+}
+
+func TestResourceSlackUsergroup_unmanagedMembers(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+	id := fake.addUsergroup("devs", "U001")
+	ctx := context.Background()
+	meta := fake.meta()
+	r := resourceSlackUsergroup()
+
+	state, diags := r.RefreshWithoutUpgrade(ctx, &terraform.InstanceState{ID: id, Attributes: map[string]string{"id": id}}, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected refresh error: %v", diags)
+	}
+	if got := state.Attributes["members.#"]; got != "1" {
+		t.Errorf("expected the members to be read, got %s", got)
+	}
+
+	// Members changed outside Terraform are not planned back
+	fake.mu.Lock()
+	fake.usergroups[id].Users = []string{"U001", "U002"}
+	fake.mu.Unlock()
+	state, diags = r.RefreshWithoutUpgrade(ctx, state, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected refresh error: %v", diags)
+	}
+
+	cfg := map[string]interface{}{
+		"handle":      "devs",
+		"description": "Developers",
+	}
+	state.RawConfig = testRawConfig(r, cfg)
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	if _, ok := diff.Attributes["members.#"]; ok {
+		t.Errorf("expected no members diff for unmanaged members, got %v", diff.Attributes["members.#"])
+	}
+	if _, diags := r.Apply(ctx, state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	if calls := fake.callCount("usergroups.users.update"); calls != 0 {
+		t.Errorf("expected no usergroups.users.update call, got %d", calls)
+	}
+	if got := fake.usergroup(id).Description; got != "Developers" {
+		t.Errorf("expected description to be updated, got %q", got)
+	}
+}
+
+func TestResourceSlackUsergroup_memberEmailsOnly(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "alice@example.com")
+	fake.addUser("U002", "bob", "bob@example.com")
+	id := fake.addUsergroup("devs", "U001", "U002")
+	ctx := context.Background()
+	meta := fake.meta()
+	r := resourceSlackUsergroup()
+
+	state, diags := r.RefreshWithoutUpgrade(ctx, &terraform.InstanceState{ID: id, Attributes: map[string]string{"id": id}}, meta)
+	if diags.HasError() {
+		t.Fatalf("unexpected refresh error: %v", diags)
+	}
+
+	// Managing the members by email removes everyone else
+	cfg := map[string]interface{}{
+		"handle":        "devs",
+		"member_emails": []interface{}{"alice@example.com"},
+	}
+	state.RawConfig = testRawConfig(r, cfg)
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("unexpected plan error: %s", err)
+	}
+	if _, diags := r.Apply(ctx, state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected apply error: %v", diags)
+	}
+	if got := fake.usergroup(id).Users; !reflect.DeepEqual(got, []string{"U001"}) {
+		t.Errorf("expected members [U001], got %v", got)
+	}
+}
+
+func TestResourceSlackUsergroup_unit(t *testing.T) {
+	skipWithoutTerraform(t)
+
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addUser("U002", "bob", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: fake.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "slack" {
+  token = "xoxb-fake"
+}
+
+resource "slack_usergroup" "test" {
+  handle  = "devs"
+  members = ["U001"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_usergroup.test", "name", "devs"),
+					resource.TestCheckResourceAttr("slack_usergroup.test", "members.#", "1"),
+				),
+			},
+			{
+				Config: `
+provider "slack" {
+  token = "xoxb-fake"
+}
+
+resource "slack_usergroup" "test" {
+  handle      = "devs"
+  description = "Developers"
+  members     = ["U001", "U002"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("slack_usergroup.test", "description", "Developers"),
+					resource.TestCheckResourceAttr("slack_usergroup.test", "members.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// testRawConfig builds the raw configuration Terraform sends alongside a
+// plan, which terraform.NewResourceConfigRaw does not fill in. It supports
+// the string, bool and string set attributes used by these tests.
+func testRawConfig(r *schema.Resource, cfg map[string]interface{}) cty.Value {
+	attrs := map[string]cty.Value{}
+	for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		switch v := cfg[name].(type) {
+		case string:
+			attrs[name] = cty.StringVal(v)
+		case bool:
+			attrs[name] = cty.BoolVal(v)
+		case []interface{}:
+			if len(v) == 0 {
+				attrs[name] = cty.SetValEmpty(ty.ElementType())
+				continue
+			}
+			elems := make([]cty.Value, 0, len(v))
+			for _, e := range v {
+				elems = append(elems, cty.StringVal(e.(string)))
+			}
+			attrs[name] = cty.SetVal(elems)
+		default:
+			attrs[name] = cty.NullVal(ty)
+		}
+	}
+	return cty.ObjectVal(attrs)
+}
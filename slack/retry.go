@@ -0,0 +1,319 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/slack-go/slack"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultRetryTimeout = 5 * time.Minute
+	minRetryBackoff     = 1 * time.Second
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// transientSlackErrors are Slack error codes that are returned with HTTP 200
+// but indicate a temporary failure on Slack's side.
+var transientSlackErrors = map[string]bool{
+	"ratelimited":         true,
+	"internal_error":      true,
+	"fatal_error":         true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+}
+
+// nonIdempotentMethods are Slack API methods that Slack may have applied even
+// when the call fails with a server error. Retrying them would then fail
+// with name_taken, already_in_channel and the like, leaving the object
+// outside the Terraform state, so they are only retried when rate limited:
+// Slack rejects those requests before acting on them.
+var nonIdempotentMethods = map[string]bool{
+	"conversations.create":                 true,
+	"conversations.invite":                 true,
+	"conversations.kick":                   true,
+	"conversations.rename":                 true,
+	"conversations.archive":                true,
+	"conversations.unarchive":              true,
+	"usergroups.create":                    true,
+	"admin.conversations.convertToPrivate": true,
+	"admin.conversations.convertToPublic":  true,
+	"admin.conversations.delete":           true,
+}
+
+// retryClient wraps a slackClient and retries calls that fail because of
+// rate limiting or transient server errors. Rate-limited calls wait for the
+// Retry-After interval returned by Slack; other transient failures use
+// exponential backoff and are not retried for nonIdempotentMethods. Every
+// call, including its retries, is bounded by timeout.
+type retryClient struct {
+	api        slackClient
+	maxRetries int
+	timeout    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+var _ slackClient = (*retryClient)(nil)
+
+func newRetryClient(api slackClient, maxRetries int, timeout time.Duration) *retryClient {
+	return &retryClient{
+		api:        api,
+		maxRetries: maxRetries,
+		timeout:    timeout,
+		minBackoff: minRetryBackoff,
+		maxBackoff: maxRetryBackoff,
+	}
+}
+
+// retry runs fn until it succeeds, fails with a non-retryable error, runs
+// out of attempts or exceeds the configured timeout.
+func (c *retryClient) retry(ctx context.Context, method string, fn func(context.Context) error) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	backoff := c.minBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		wait, retryable := c.retryDelay(err, backoff, nonIdempotentMethods[method])
+		if !retryable || attempt >= c.maxRetries {
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf("giving up on %s after %d attempts, retry timeout exceeded: %w", method, attempt+1, err)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Slack API call %s failed (%s), retrying in %s (attempt %d of %d)", method, err, wait, attempt+1, c.maxRetries))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+// retryDelay reports whether err is worth retrying and how long to wait
+// first. With rateLimitOnly, only rate limiting is retried.
+func (c *retryClient) retryDelay(err error, backoff time.Duration, rateLimitOnly bool) (time.Duration, bool) {
+	var rateLimited *slack.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		if rateLimited.RetryAfter > 0 {
+			return rateLimited.RetryAfter, true
+		}
+		return backoff, true
+	}
+
+	var statusErr slack.StatusCodeError
+	if errors.As(err, &statusErr) {
+		if rateLimitOnly {
+			return backoff, statusErr.Code == http.StatusTooManyRequests
+		}
+		return backoff, statusErr.Retryable()
+	}
+
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		if rateLimitOnly {
+			return backoff, slackErr.Err == "ratelimited"
+		}
+		return backoff, transientSlackErrors[slackErr.Err]
+	}
+
+	return 0, false
+}
+
+func (c *retryClient) AuthTestContext(ctx context.Context) (resp *slack.AuthTestResponse, err error) {
+	err = c.retry(ctx, "auth.test", func(ctx context.Context) error {
+		resp, err = c.api.AuthTestContext(ctx)
+		return err
+	})
+	return resp, err
+}
+
+func (c *retryClient) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) (channels []slack.Channel, cursor string, err error) {
+	err = c.retry(ctx, "conversations.list", func(ctx context.Context) error {
+		channels, cursor, err = c.api.GetConversationsContext(ctx, params)
+		return err
+	})
+	return channels, cursor, err
+}
+
+func (c *retryClient) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (channel *slack.Channel, err error) {
+	err = c.retry(ctx, "conversations.info", func(ctx context.Context) error {
+		channel, err = c.api.GetConversationInfoContext(ctx, input)
+		return err
+	})
+	return channel, err
+}
+
+func (c *retryClient) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) (members []string, cursor string, err error) {
+	err = c.retry(ctx, "conversations.members", func(ctx context.Context) error {
+		members, cursor, err = c.api.GetUsersInConversationContext(ctx, params)
+		return err
+	})
+	return members, cursor, err
+}
+
+func (c *retryClient) CreateConversationContext(ctx context.Context, params slack.CreateConversationParams) (channel *slack.Channel, err error) {
+	err = c.retry(ctx, "conversations.create", func(ctx context.Context) error {
+		channel, err = c.api.CreateConversationContext(ctx, params)
+		return err
+	})
+	return channel, err
+}
+
+func (c *retryClient) RenameConversationContext(ctx context.Context, channelID, channelName string) (channel *slack.Channel, err error) {
+	err = c.retry(ctx, "conversations.rename", func(ctx context.Context) error {
+		channel, err = c.api.RenameConversationContext(ctx, channelID, channelName)
+		return err
+	})
+	return channel, err
+}
+
+func (c *retryClient) SetPurposeOfConversationContext(ctx context.Context, channelID, purpose string) (channel *slack.Channel, err error) {
+	err = c.retry(ctx, "conversations.setPurpose", func(ctx context.Context) error {
+		channel, err = c.api.SetPurposeOfConversationContext(ctx, channelID, purpose)
+		return err
+	})
+	return channel, err
+}
+
+func (c *retryClient) SetTopicOfConversationContext(ctx context.Context, channelID, topic string) (channel *slack.Channel, err error) {
+	err = c.retry(ctx, "conversations.setTopic", func(ctx context.Context) error {
+		channel, err = c.api.SetTopicOfConversationContext(ctx, channelID, topic)
+		return err
+	})
+	return channel, err
+}
+
+func (c *retryClient) InviteUsersToConversationContext(ctx context.Context, channelID string, users ...string) (channel *slack.Channel, err error) {
+	err = c.retry(ctx, "conversations.invite", func(ctx context.Context) error {
+		channel, err = c.api.InviteUsersToConversationContext(ctx, channelID, users...)
+		return err
+	})
+	return channel, err
+}
+
+func (c *retryClient) KickUserFromConversationContext(ctx context.Context, channelID string, user string) error {
+	return c.retry(ctx, "conversations.kick", func(ctx context.Context) error {
+		return c.api.KickUserFromConversationContext(ctx, channelID, user)
+	})
+}
+
+func (c *retryClient) JoinConversationContext(ctx context.Context, channelID string) (channel *slack.Channel, warning string, warnings []string, err error) {
+	err = c.retry(ctx, "conversations.join", func(ctx context.Context) error {
+		channel, warning, warnings, err = c.api.JoinConversationContext(ctx, channelID)
+		return err
+	})
+	return channel, warning, warnings, err
+}
+
+func (c *retryClient) ArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.retry(ctx, "conversations.archive", func(ctx context.Context) error {
+		return c.api.ArchiveConversationContext(ctx, channelID)
+	})
+}
+
+func (c *retryClient) UnArchiveConversationContext(ctx context.Context, channelID string) error {
+	return c.retry(ctx, "conversations.unarchive", func(ctx context.Context) error {
+		return c.api.UnArchiveConversationContext(ctx, channelID)
+	})
+}
+
+func (c *retryClient) GetUserInfoContext(ctx context.Context, user string) (info *slack.User, err error) {
+	err = c.retry(ctx, "users.info", func(ctx context.Context) error {
+		info, err = c.api.GetUserInfoContext(ctx, user)
+		return err
+	})
+	return info, err
+}
+
+func (c *retryClient) GetUserByEmailContext(ctx context.Context, email string) (user *slack.User, err error) {
+	err = c.retry(ctx, "users.lookupByEmail", func(ctx context.Context) error {
+		user, err = c.api.GetUserByEmailContext(ctx, email)
+		return err
+	})
+	return user, err
+}
+
+func (c *retryClient) GetUsersContext(ctx context.Context, options ...slack.GetUsersOption) (users []slack.User, err error) {
+	err = c.retry(ctx, "users.list", func(ctx context.Context) error {
+		users, err = c.api.GetUsersContext(ctx, options...)
+		return err
+	})
+	return users, err
+}
+
+func (c *retryClient) CreateUserGroupContext(ctx context.Context, userGroup slack.UserGroup, options ...slack.CreateUserGroupOption) (group slack.UserGroup, err error) {
+	err = c.retry(ctx, "usergroups.create", func(ctx context.Context) error {
+		group, err = c.api.CreateUserGroupContext(ctx, userGroup, options...)
+		return err
+	})
+	return group, err
+}
+
+func (c *retryClient) GetUserGroupsContext(ctx context.Context, options ...slack.GetUserGroupsOption) (groups []slack.UserGroup, err error) {
+	err = c.retry(ctx, "usergroups.list", func(ctx context.Context) error {
+		groups, err = c.api.GetUserGroupsContext(ctx, options...)
+		return err
+	})
+	return groups, err
+}
+
+func (c *retryClient) GetUserGroupMembersContext(ctx context.Context, userGroup string) (members []string, err error) {
+	err = c.retry(ctx, "usergroups.users.list", func(ctx context.Context) error {
+		members, err = c.api.GetUserGroupMembersContext(ctx, userGroup)
+		return err
+	})
+	return members, err
+}
+
+func (c *retryClient) UpdateUserGroupContext(ctx context.Context, userGroupID string, options ...slack.UpdateUserGroupsOption) (group slack.UserGroup, err error) {
+	err = c.retry(ctx, "usergroups.update", func(ctx context.Context) error {
+		group, err = c.api.UpdateUserGroupContext(ctx, userGroupID, options...)
+		return err
+	})
+	return group, err
+}
+
+func (c *retryClient) UpdateUserGroupMembersContext(ctx context.Context, userGroup string, members string) (group slack.UserGroup, err error) {
+	err = c.retry(ctx, "usergroups.users.update", func(ctx context.Context) error {
+		group, err = c.api.UpdateUserGroupMembersContext(ctx, userGroup, members)
+		return err
+	})
+	return group, err
+}
+
+func (c *retryClient) DisableUserGroupContext(ctx context.Context, userGroup string) (group slack.UserGroup, err error) {
+	err = c.retry(ctx, "usergroups.disable", func(ctx context.Context) error {
+		group, err = c.api.DisableUserGroupContext(ctx, userGroup)
+		return err
+	})
+	return group, err
+}
+
+func (c *retryClient) EnableUserGroupContext(ctx context.Context, userGroup string) (group slack.UserGroup, err error) {
+	err = c.retry(ctx, "usergroups.enable", func(ctx context.Context) error {
+		group, err = c.api.EnableUserGroupContext(ctx, userGroup)
+		return err
+	})
+	return group, err
+}
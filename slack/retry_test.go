@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// flakyClient fails AuthTestContext with the queued errors before succeeding.
+type flakyClient struct {
+	slackClient
+	errs  []error
+	calls int
+}
+
+func (c *flakyClient) AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error) {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return nil, err
+	}
+	return &slack.AuthTestResponse{UserID: fakeBotUserID}, nil
+}
+
+func newTestRetryClient(api slackClient, maxRetries int) *retryClient {
+	c := newRetryClient(api, maxRetries, time.Minute)
+	c.minBackoff = time.Millisecond
+	c.maxBackoff = time.Millisecond
+	return c
+}
+
+func TestRetryClient(t *testing.T) {
+	cases := []struct {
+		name       string
+		errs       []error
+		maxRetries int
+		wantErr    bool
+		wantCalls  int
+	}{
+		{"success", nil, 3, false, 1},
+		{"rate limited", []error{&slack.RateLimitedError{RetryAfter: time.Millisecond}}, 3, false, 2},
+		{"server error", []error{slack.StatusCodeError{Code: http.StatusBadGateway}, slack.StatusCodeError{Code: http.StatusServiceUnavailable}}, 3, false, 3},
+		{"transient slack error", []error{slack.SlackErrorResponse{Err: "internal_error"}}, 3, false, 2},
+		{"not retryable", []error{slack.SlackErrorResponse{Err: "invalid_auth"}}, 3, true, 1},
+		{"client error", []error{slack.StatusCodeError{Code: http.StatusBadRequest}}, 3, true, 1},
+		{"retries exhausted", []error{slack.StatusCodeError{Code: 500}, slack.StatusCodeError{Code: 500}, slack.StatusCodeError{Code: 500}}, 2, true, 3},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &flakyClient{errs: tt.errs}
+			_, err := newTestRetryClient(api, tt.maxRetries).AuthTestContext(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got %v", tt.wantErr, err)
+			}
+			if api.calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, api.calls)
+			}
+		})
+	}
+}
+
+func TestRetryClient_timeout(t *testing.T) {
+	api := &flakyClient{errs: []error{&slack.RateLimitedError{RetryAfter: time.Hour}}}
+	c := newRetryClient(api, 3, time.Second)
+
+	_, err := c.AuthTestContext(context.Background())
+	var rateLimited *slack.RateLimitedError
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("expected rate limit error after timeout, got %v", err)
+	}
+	if api.calls != 1 {
+		t.Errorf("expected 1 call, got %d", api.calls)
+	}
+}
+
+func TestRetryClient_nonIdempotent(t *testing.T) {
+	cases := []struct {
+		name      string
+		method    string
+		err       error
+		wantCalls int
+	}{
+		{"server error on create", "conversations.create", slack.StatusCodeError{Code: http.StatusBadGateway}, 1},
+		{"transient slack error on invite", "conversations.invite", slack.SlackErrorResponse{Err: "internal_error"}, 1},
+		{"rate limited create", "usergroups.create", &slack.RateLimitedError{RetryAfter: time.Millisecond}, 2},
+		{"429 on create", "usergroups.create", slack.StatusCodeError{Code: http.StatusTooManyRequests}, 2},
+		{"ratelimited code on invite", "conversations.invite", slack.SlackErrorResponse{Err: "ratelimited"}, 2},
+		{"server error on update", "usergroups.update", slack.StatusCodeError{Code: http.StatusBadGateway}, 2},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := newTestRetryClient(nil, 3).retry(context.Background(), tt.method, func(context.Context) error {
+				calls++
+				if calls == 1 {
+					return tt.err
+				}
+				return nil
+			})
+			if wantErr := tt.wantCalls == 1; (err != nil) != wantErr {
+				t.Errorf("expected error: %v, got %v", wantErr, err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
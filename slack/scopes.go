@@ -0,0 +1,232 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scopeRequirement is met when the token that makes the calls has any one
+// of the listed scopes. A requirement with a when function only applies to
+// configurations for which it returns true.
+type scopeRequirement struct {
+	scopes []string
+	token  tokenClass
+	when   func(d *schema.ResourceDiff) bool
+}
+
+func (r scopeRequirement) String() string {
+	return strings.Join(r.scopes, " or ")
+}
+
+// anyOf builds a bot token requirement that always applies.
+func anyOf(scopes ...string) scopeRequirement {
+	return scopeRequirement{scopes: scopes}
+}
+
+// kickScopes are the scopes conversations.kick accepts from a bot or a user
+// token.
+var kickScopes = []string{"channels:manage", "channels:write", "groups:write"}
+
+// requiredScopes lists the OAuth scopes each resource and data source needs.
+var requiredScopes = map[string][]scopeRequirement{
+	"slack_channel": {
+		anyOf("channels:read", "groups:read"),
+		anyOf("channels:manage", "groups:write"),
+		{scopes: []string{"channels:join"}, when: channelJoins},
+		{scopes: []string{"users:read"}, when: whenSet("member_emails")},
+		{scopes: []string{"users:read.email"}, when: whenSet("member_emails")},
+		{scopes: []string{"usergroups:read"}, when: whenSet("member_usergroup_ids")},
+		{scopes: []string{"users:read"}, when: whenSet("member_usergroup_ids")},
+		{scopes: kickScopes, token: tokenUser, when: func(d *schema.ResourceDiff) bool {
+			return d.Get("strict_members").(bool)
+		}},
+		{scopes: []string{"admin.conversations:write"}, token: tokenAdmin, when: channelAdminCalls},
+	},
+	"slack_channel_member": {
+		anyOf("channels:read", "groups:read"),
+		anyOf("channels:manage", "groups:write"),
+		{scopes: kickScopes, token: tokenUser},
+	},
+	"slack_usergroup": {
+		anyOf("usergroups:read"),
+		anyOf("usergroups:write"),
+		{scopes: []string{"users:read"}, when: whenSet("member_emails")},
+		{scopes: []string{"users:read.email"}, when: whenSet("member_emails")},
+	},
+	"slack_usergroup_member": {
+		anyOf("usergroups:read"),
+		anyOf("usergroups:write"),
+	},
+
+	"data.slack_channel":     {anyOf("channels:read", "groups:read")},
+	"data.slack_channels":    {anyOf("channels:read", "groups:read")},
+	"data.slack_user":        {anyOf("users:read"), anyOf("users:read.email")},
+	"data.slack_users":       {anyOf("users:read")},
+	"data.slack_users_group": {anyOf("users:read"), anyOf("users:read.email")},
+	"data.slack_usergroup":   {anyOf("usergroups:read")},
+	"data.slack_usergroups":  {anyOf("usergroups:read")},
+}
+
+// whenSet limits a requirement to configurations that set key.
+func whenSet(key string) func(d *schema.ResourceDiff) bool {
+	return func(d *schema.ResourceDiff) bool {
+		config := d.GetRawConfig()
+		return !config.IsNull() && config.IsKnown() && !config.GetAttr(key).IsNull()
+	}
+}
+
+// channelJoins reports whether the bot may join the channel: to adopt an
+// existing channel on create, or to archive it on destroy.
+func channelJoins(d *schema.ResourceDiff) bool {
+	adopts := d.Id() == "" && d.Get("on_conflict").(string) != channelConflictFail
+	return adopts || d.Get("deletion_policy").(string) == channelDeletionArchive
+}
+
+// channelAdminCalls reports whether the admin token may be used: to convert
+// the channel in place, or to delete it on destroy.
+func channelAdminCalls(d *schema.ResourceDiff) bool {
+	converts := d.Id() != "" && d.HasChange("is_private")
+	return converts || d.Get("deletion_policy").(string) == channelDeletionDelete
+}
+
+// scopeSet holds the OAuth scopes granted to a token. A nil scopeSet means
+// the scopes are unknown, for example because a proxy stripped the header,
+// and every check passes.
+type scopeSet map[string]bool
+
+func newScopeSet(scopes []string) scopeSet {
+	set := make(scopeSet, len(scopes))
+	for _, s := range scopes {
+		set[s] = true
+	}
+	return set
+}
+
+// meets reports whether the scopes meet req.
+func (s scopeSet) meets(req scopeRequirement) bool {
+	if s == nil {
+		return true
+	}
+	for _, scope := range req.scopes {
+		if s[scope] {
+			return true
+		}
+	}
+	return false
+}
+
+// scopesFor returns the scopes of the token clientFor picks for class. They
+// are nil, so every check passes, when Slack did not report them or no admin
+// token is configured. requireToken reports a missing admin token.
+func (m *providerMeta) scopesFor(class tokenClass) scopeSet {
+	switch class {
+	case tokenUser:
+		if m.userClient != nil {
+			return m.userScopes
+		}
+	case tokenAdmin:
+		return m.adminScopes
+	}
+	return m.scopes
+}
+
+// missingScopes returns the requirements of name that the tokens making the
+// calls do not meet. d is nil for data sources, which only have requirements
+// that always apply.
+func (m *providerMeta) missingScopes(name string, d *schema.ResourceDiff) []scopeRequirement {
+	var missing []scopeRequirement
+	for _, req := range requiredScopes[name] {
+		if req.when != nil && (d == nil || !req.when(d)) {
+			continue
+		}
+		if !m.scopesFor(req.token).meets(req) {
+			missing = append(missing, req)
+		}
+	}
+	return missing
+}
+
+// checkScopes returns an error naming the scopes the tokens lack for the
+// resource or data source name.
+func (m *providerMeta) checkScopes(name string, d *schema.ResourceDiff) error {
+	missing := m.missingScopes(name, d)
+	if len(missing) == 0 {
+		return nil
+	}
+	reqs := make([]string, len(missing))
+	for i, req := range missing {
+		reqs[i] = req.String()
+		if req.token != tokenBot && m.hasToken(req.token) {
+			reqs[i] += " on " + req.token.argument()
+		}
+	}
+	return fmt.Errorf("%s requires %s: add the missing OAuth scopes to your Slack app and reinstall it", strings.TrimPrefix(name, "data."), strings.Join(reqs, " and "))
+}
+
+// requireScopes returns a CustomizeDiffFunc that fails the plan when a token
+// lacks a scope the resource name needs with this configuration.
+func requireScopes(name string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		m, ok := meta.(*providerMeta)
+		if !ok {
+			return nil
+		}
+		return m.checkScopes(name, d)
+	}
+}
+
+// checkDataSourceScopes is the data source counterpart of requireScopes.
+func checkDataSourceScopes(meta interface{}, name string) diag.Diagnostics {
+	if err := meta.(*providerMeta).checkScopes("data."+name, nil); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// scopeRecorder is an http.RoundTripper that records the OAuth scopes Slack
+// reports in the X-OAuth-Scopes response header.
+type scopeRecorder struct {
+	base http.RoundTripper
+
+	mu     sync.Mutex
+	scopes []string
+	seen   bool
+}
+
+func (r *scopeRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if values, ok := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok {
+		var scopes []string
+		for _, v := range values {
+			for _, s := range strings.Split(v, ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					scopes = append(scopes, s)
+				}
+			}
+		}
+		r.mu.Lock()
+		r.scopes = scopes
+		r.seen = true
+		r.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// granted returns the recorded scopes, or nil if Slack has not reported any.
+func (r *scopeRecorder) granted() scopeSet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.seen {
+		return nil
+	}
+	return newScopeSet(r.scopes)
+}
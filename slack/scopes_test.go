@@ -0,0 +1,116 @@
+package slack
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProviderConfigure_scopes(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.scopes = []string{"channels:read", "channels:manage", "usergroups:read", "users:read"}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"bot_token": "xoxb-fake",
+		"api_url":   fake.server.URL,
+	})
+	meta, diags := providerConfigure(Provider())(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected configure error: %v", diags)
+	}
+	m := meta.(*providerMeta)
+
+	if !m.scopes["usergroups:read"] || len(m.scopes) != 4 {
+		t.Errorf("expected the four granted scopes, got %v", m.scopes)
+	}
+	if err := m.checkScopes("slack_channel", nil); err != nil {
+		t.Errorf("unexpected error for slack_channel: %s", err)
+	}
+	err := m.checkScopes("slack_usergroup", nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "slack_usergroup requires usergroups:write") {
+		t.Errorf("expected a missing usergroups:write error, got %v", err)
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceSlackUsersGroup().Schema, map[string]interface{}{})
+	diags = dataSourceSlackUsersGroupRead(context.Background(), d, m)
+	if !diags.HasError() || !strings.HasPrefix(diags[0].Summary, "slack_users_group requires users:read.email") {
+		t.Errorf("expected a missing users:read.email error, got %v", diags)
+	}
+}
+
+func TestProviderConfigure_tokenScopes(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("U001", "alice", "")
+	fake.addToken("xoxp-user", "U001")
+	fake.addToken("xoxp-admin", "U001")
+	fake.scopes = []string{"channels:read", "channels:manage", "channels:join"}
+	fake.tokenScopes["xoxp-user"] = []string{"channels:read"}
+	fake.tokenScopes["xoxp-admin"] = []string{"admin.teams:read"}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"bot_token":   "xoxb-fake",
+		"user_token":  "xoxp-user",
+		"admin_token": "xoxp-admin",
+		"api_url":     fake.server.URL,
+	})
+	meta, diags := providerConfigure(Provider())(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected configure error: %v", diags)
+	}
+	m := meta.(*providerMeta)
+
+	if !m.userScopes["channels:read"] || len(m.userScopes) != 1 {
+		t.Errorf("expected the user token's scopes, got %v", m.userScopes)
+	}
+	if !m.adminScopes["admin.teams:read"] || len(m.adminScopes) != 1 {
+		t.Errorf("expected the admin token's scopes, got %v", m.adminScopes)
+	}
+
+	// Kicks are made with the user token
+	err := m.checkScopes("slack_channel_member", nil)
+	if err == nil || !strings.Contains(err.Error(), "channels:manage or channels:write or groups:write on user_token") {
+		t.Errorf("expected a missing user token scope error, got %v", err)
+	}
+}
+
+func TestRequireScopes_conditional(t *testing.T) {
+	fake := newFakeSlack(t)
+	fake.addUser("UADMIN0001", "admin", "")
+	meta := fake.adminMeta("UADMIN0001")
+	meta.scopes = newScopeSet([]string{"channels:read", "channels:manage"})
+	meta.adminScopes = newScopeSet([]string{"admin.teams:read"})
+	r := resourceSlackChannel()
+
+	cases := []struct {
+		name    string
+		cfg     map[string]interface{}
+		missing string
+	}{
+		{"no joins", map[string]interface{}{"name": "tf-scopes", "on_conflict": "fail", "deletion_policy": "abandon"}, ""},
+		{"defaults", map[string]interface{}{"name": "tf-scopes"}, "channels:join"},
+		{"member_emails", map[string]interface{}{"name": "tf-scopes", "on_conflict": "fail", "deletion_policy": "abandon", "member_emails": []interface{}{"bob@example.com"}}, "users:read and users:read.email"},
+		{"member_usergroup_ids", map[string]interface{}{"name": "tf-scopes", "on_conflict": "fail", "deletion_policy": "abandon", "member_usergroup_ids": []interface{}{"S001"}}, "usergroups:read and users:read"},
+		{"deletion_policy", map[string]interface{}{"name": "tf-scopes", "on_conflict": "fail", "deletion_policy": "delete"}, "admin.conversations:write on admin_token"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), &terraform.InstanceState{RawConfig: testRawConfig(r, tc.cfg)}, terraform.NewResourceConfigRaw(tc.cfg), meta)
+			switch {
+			case tc.missing == "" && err != nil:
+				t.Errorf("unexpected plan error: %s", err)
+			case tc.missing != "" && (err == nil || !strings.Contains(err.Error(), "slack_channel requires "+tc.missing+":")):
+				t.Errorf("expected a plan error for %s, got %v", tc.missing, err)
+			}
+		})
+	}
+}
+
+func TestScopeSet_unknown(t *testing.T) {
+	m := &providerMeta{}
+	if missing := m.missingScopes("slack_usergroup", nil); missing != nil {
+		t.Errorf("expected unknown scopes to pass every check, got %v", missing)
+	}
+}
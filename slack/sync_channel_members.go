@@ -1,35 +1,36 @@
 package slack
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/slack-go/slack"
 )
 
 // syncChannelMembers ensures that all desired members are in the Slack channel.
-// It adds missing members and warns about extra ones.
+// It adds missing members and handles extra ones according to strictMembers:
 //
-// Slack API does NOT support removing users via API, so extra users are only warned.
-func syncChannelMembers(api *slack.Client, channelID string, desiredMembers []string) diag.Diagnostics {
+//   - strictMembers = false: extra members are left in place and only warned about.
+//   - strictMembers = true: extra members are removed via conversations.kick.
+//
+// The bot and any user listed in exemptMembers are never removed. Kicks use
+// the user token when one is configured; a kick of the token's own user, or
+// one the token cannot make because its user is not in the channel, falls
+// back to the bot token.
+func syncChannelMembers(ctx context.Context, m *providerMeta, channelID string, desiredMembers []string, strictMembers bool, exemptMembers []string) diag.Diagnostics {
 	var diags diag.Diagnostics
+	api := m.client
+	defer m.cache.InvalidateChannelMembers(channelID)
 
 	// Get current channel members from Slack
-	currentMembers, _, err := api.GetUsersInConversation(&slack.GetUsersInConversationParameters{
-		ChannelID: channelID,
-		Limit:     1000,
-	})
+	currentMembers, err := getChannelMembers(ctx, api, channelID)
 	if err != nil {
-		return diag.Errorf("failed to retrieve current channel members from Slack: %s", err)
+		return slackDiagErrorf(err, "conversations.members", "failed to retrieve current channel members from Slack")
 	}
 
-	// Fetch bot ID (used to suppress its warning)
-	auth, authErr := api.AuthTest()
-	botID := ""
-	if authErr == nil {
-		botID = auth.UserID
-	}
+	// The bot is never warned about nor removed
+	botUserID := m.botUserID(ctx)
 
 	// Create lookup sets
 	currentSet := make(map[string]bool)
@@ -40,6 +41,10 @@ func syncChannelMembers(api *slack.Client, channelID string, desiredMembers []st
 	for _, user := range desiredMembers {
 		desiredSet[user] = true
 	}
+	exemptSet := make(map[string]bool)
+	for _, user := range exemptMembers {
+		exemptSet[user] = true
+	}
 
 	// Identify members to add
 	var toAdd []string
@@ -49,24 +54,52 @@ func syncChannelMembers(api *slack.Client, channelID string, desiredMembers []st
 		}
 	}
 	if len(toAdd) > 0 {
-		_, err := api.InviteUsersToConversation(channelID, toAdd...)
+		_, err := api.InviteUsersToConversationContext(ctx, channelID, toAdd...)
 		if err != nil {
-			return diag.Errorf("error adding members to Slack channel: %s", err)
+			return slackDiagErrorf(err, "conversations.invite", "error adding members to Slack channel")
 		}
 	}
 
-	// Identify "extra" users, excluding the bot itself
-	var extras []string
+	// Identify "extra" users, excluding the bot and exempt users
+	var extraIDs []string
 	for _, user := range currentMembers {
-		if !desiredSet[user] && user != botID {
-			// Try to resolve user info
-			info, err := api.GetUserInfo(user)
-			if err == nil {
-				extras = append(extras, fmt.Sprintf("%s (%s)", info.Name, info.Profile.Email))
-			} else {
-				extras = append(extras, fmt.Sprintf("Unknown (%s)", user))
+		if !desiredSet[user] && user != botUserID && !exemptSet[user] {
+			extraIDs = append(extraIDs, user)
+		}
+	}
+
+	if strictMembers {
+		kickAPI, err := m.clientFor(tokenUser, "conversations.kick")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		// Kick the user token's own user last, while the token can still act
+		// in the channel
+		if hasMember(extraIDs, m.userTokenUserID) {
+			extraIDs = append(removeMember(extraIDs, m.userTokenUserID), m.userTokenUserID)
+		}
+		for _, user := range extraIDs {
+			if err := kickChannelMember(ctx, m, kickAPI, channelID, user); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to remove member from Slack channel",
+					Detail: fmt.Sprintf("Terraform could not remove user '%s' from channel '%s': %s. %s "+
+						"Add the user to 'exempt_members' if they should stay in the channel.", user, channelID, err, slackErrorDetail(err, "conversations.kick")),
+				})
 			}
 		}
+		return diags
+	}
+
+	var extras []string
+	for _, user := range extraIDs {
+		// Try to resolve user info from the cached user directory
+		info, err := m.cache.UserByID(ctx, user)
+		if err == nil && info != nil {
+			extras = append(extras, fmt.Sprintf("%s (%s)", info.Name, info.Profile.Email))
+		} else {
+			extras = append(extras, fmt.Sprintf("Unknown (%s)", user))
+		}
 	}
 
 	// Warn if extras exist (excluding bot)
@@ -74,10 +107,28 @@ func syncChannelMembers(api *slack.Client, channelID string, desiredMembers []st
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Warning,
 			Summary:  "Extra members found in Slack channel",
-			Detail: fmt.Sprintf("Slack API does not support removing users via Terraform.\nExtra members present in the channel but not declared:\n- %s",
+			Detail: fmt.Sprintf("Extra members present in the channel but not declared:\n- %s\n\nSet 'strict_members = true' to have Terraform remove them.",
 				strings.Join(extras, "\n- ")),
 		})
 	}
 
 	return diags
 }
+
+// kickChannelMember removes user from the channel with api, the user token
+// client if one is configured. A user token cannot kick its own user and
+// cannot kick anyone from a channel its user is not in, so those kicks are
+// made with the bot token instead.
+func kickChannelMember(ctx context.Context, m *providerMeta, api slackClient, channelID, user string) error {
+	if api == m.client {
+		return api.KickUserFromConversationContext(ctx, channelID, user)
+	}
+	if user == m.userTokenUserID {
+		return m.client.KickUserFromConversationContext(ctx, channelID, user)
+	}
+	err := api.KickUserFromConversationContext(ctx, channelID, user)
+	if slackErrorCode(err) == "not_in_channel" {
+		return m.client.KickUserFromConversationContext(ctx, channelID, user)
+	}
+	return err
+}
@@ -0,0 +1,106 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tokenClass identifies which configured Slack token an API call needs.
+type tokenClass int
+
+const (
+	// tokenBot is the default bot token (xoxb-).
+	tokenBot tokenClass = iota
+	// tokenUser is a user token (xoxp-) for calls made on behalf of a user.
+	// It falls back to the bot token when no user token is configured.
+	tokenUser
+	// tokenAdmin is an admin user token required by admin.* methods.
+	tokenAdmin
+)
+
+func (c tokenClass) argument() string {
+	switch c {
+	case tokenUser:
+		return "user_token"
+	case tokenAdmin:
+		return "admin_token"
+	}
+	return "bot_token"
+}
+
+// hasToken reports whether a token of the given class is configured.
+func (m *providerMeta) hasToken(class tokenClass) bool {
+	switch class {
+	case tokenUser:
+		return m.userClient != nil
+	case tokenAdmin:
+		return m.adminClient != nil
+	}
+	return m.client != nil
+}
+
+// clientFor returns the client to use for operation, which needs a token of
+// the given class.
+func (m *providerMeta) clientFor(class tokenClass, operation string) (slackClient, error) {
+	switch class {
+	case tokenAdmin:
+		if m.adminClient == nil {
+			return nil, missingTokenError(class, operation)
+		}
+		return m.adminClient, nil
+	case tokenUser:
+		if m.userClient != nil {
+			return m.userClient, nil
+		}
+	}
+	return m.client, nil
+}
+
+// adminAPI returns the client for admin.* methods.
+func (m *providerMeta) adminAPI(operation string) (slackAdminClient, error) {
+	if m.admin == nil {
+		return nil, missingTokenError(tokenAdmin, operation)
+	}
+	return m.admin, nil
+}
+
+// requireToken returns a CustomizeDiffFunc that fails the plan when when(d)
+// is true and no token of the given class is configured, so a missing token
+// is reported before any change is applied.
+func requireToken(class tokenClass, operation string, when func(d *schema.ResourceDiff) bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		m, ok := meta.(*providerMeta)
+		if !ok || m.hasToken(class) || !when(d) {
+			return nil
+		}
+		return missingTokenError(class, operation)
+	}
+}
+
+// missingTokenError explains that operation needs a token that is not configured.
+func missingTokenError(class tokenClass, operation string) error {
+	return fmt.Errorf("%s requires a Slack %s: set '%s' in the provider configuration", operation, class.description(), class.argument())
+}
+
+func (c tokenClass) description() string {
+	switch c {
+	case tokenUser:
+		return "user token (xoxp-)"
+	case tokenAdmin:
+		return "admin user token (xoxp-) with admin.* scopes"
+	}
+	return "bot token (xoxb-)"
+}
+
+// botUserID returns the user behind the bot token, or an empty string if it
+// cannot be determined. The bot is never treated as a declared or undeclared
+// channel member. The user behind user_token gets no such exemption.
+func (m *providerMeta) botUserID(ctx context.Context) string {
+	bot, err := m.cache.Bot(ctx)
+	if err != nil {
+		return ""
+	}
+	return bot.UserID
+}
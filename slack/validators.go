@@ -0,0 +1,67 @@
+package slack
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxSlackNameLength is the longest channel name or usergroup handle Slack
+// accepts.
+const maxSlackNameLength = 80
+
+// validateSlackName returns a validator for channel names and usergroup
+// handles. Slack requires them to be lowercase, at most 80 characters long,
+// made of letters, digits, hyphens and underscores (and periods, for
+// handles), and to start with a letter or digit.
+func validateSlackName(kind string, allowPeriod bool) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := v.(string)
+		if !ok {
+			return diag.Diagnostics{invalidSlackName(path, kind, "expected a string")}
+		}
+
+		var diags diag.Diagnostics
+		if value == "" {
+			return append(diags, invalidSlackName(path, kind, "must not be empty"))
+		}
+		if n := utf8.RuneCountInString(value); n > maxSlackNameLength {
+			diags = append(diags, invalidSlackName(path, kind, fmt.Sprintf("must be at most %d characters long, got %d", maxSlackNameLength, n)))
+		}
+
+		first, _ := utf8.DecodeRuneInString(value)
+		if !unicode.IsLetter(first) && !unicode.IsDigit(first) {
+			diags = append(diags, invalidSlackName(path, kind, fmt.Sprintf("must start with a letter or digit, got %q", first)))
+		}
+
+		allowed := "letters, digits, hyphens and underscores"
+		if allowPeriod {
+			allowed = "letters, digits, hyphens, underscores and periods"
+		}
+		for _, r := range value {
+			if unicode.IsUpper(r) {
+				diags = append(diags, invalidSlackName(path, kind, fmt.Sprintf("must be lowercase, got %q", value)))
+				break
+			}
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && (r != '.' || !allowPeriod) {
+				diags = append(diags, invalidSlackName(path, kind, fmt.Sprintf("may only contain %s, got %q", allowed, r)))
+				break
+			}
+		}
+
+		return diags
+	}
+}
+
+func invalidSlackName(path cty.Path, kind, problem string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("Invalid Slack %s", kind),
+		Detail:        fmt.Sprintf("The %s %s.", kind, problem),
+		AttributePath: path,
+	}
+}
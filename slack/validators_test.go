@@ -0,0 +1,36 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateSlackName(t *testing.T) {
+	cases := []struct {
+		value       string
+		allowPeriod bool
+		valid       bool
+	}{
+		{"devops-alerts", false, true},
+		{"team_2024", false, true},
+		{"équipe", false, true},
+		{"", false, false},
+		{"DevOps", false, false},
+		{"dev ops", false, false},
+		{"dev.ops", false, false},
+		{"dev.ops", true, true},
+		{"-devops", false, false},
+		{"_devops", true, false},
+		{strings.Repeat("a", 80), false, true},
+		{strings.Repeat("a", 81), false, false},
+	}
+
+	for _, tc := range cases {
+		diags := validateSlackName("channel name", tc.allowPeriod)(tc.value, cty.GetAttrPath("name"))
+		if valid := !diags.HasError(); valid != tc.valid {
+			t.Errorf("%q (allowPeriod %v): expected valid = %v, got %v (%v)", tc.value, tc.allowPeriod, tc.valid, valid, diags)
+		}
+	}
+}